@@ -0,0 +1,195 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+type AnalyzeCommand struct {
+	Game int `short:"g" long:"game" default:"1" description:"Which game of the day to analyze"`
+
+	Args struct {
+		Date string `positional-arg-name:"date" description:"Day the game was played, as YYYY-MM-DD"`
+	} `positional-args:"yes" required:"yes"`
+}
+
+// guessAnalysis is how one guess of a game compares to the best guess that
+// could have been made in its place.
+type guessAnalysis struct {
+	guess      string
+	possible   int
+	worst      int
+	bits       float64
+	bestWorst  int
+	worstGuess string
+	bestBits   float64
+	bitsGuess  string
+}
+
+func (cmd *AnalyzeCommand) Execute(_ []string) error {
+	day, err := time.ParseInLocation("2006-01-02", cmd.Args.Date, time.Local)
+	if err != nil {
+		return fmt.Errorf("invalid date %q, expected YYYY-MM-DD", cmd.Args.Date)
+	}
+
+	gamestats := loadGameStats()
+
+	record, err := gamestats.findGame(day, cmd.Game)
+	if err != nil {
+		return err
+	}
+
+	if len(record.Guesses) == 0 {
+		return errors.New("that game's guesses weren't recorded, only its colors")
+	}
+
+	parseWordLists()
+
+	evalCache, err = loadEvalCache()
+	if err != nil {
+		fmt.Printf("(feedback not cached: %v)\n", err)
+	}
+
+	fmt.Printf("Analysis of %s", record.Answer)
+
+	if record.HardMode {
+		fmt.Print(", hard mode")
+	}
+
+	fmt.Print("\n\n")
+
+	pool := (&GameStats{}).suggestionPool(SuggestFull)
+	safe := true
+
+	for i := range record.Guesses {
+		a := analyzeGuess(record.Guesses[:i], record.Guesses[i], record.Answer, record.HardMode, pool)
+
+		fmt.Printf("%d %s  %d possible\n", i+1, a.guess, a.possible)
+		fmt.Printf("  worst case %d left, best possible %d (%s)\n", a.worst, a.bestWorst, a.worstGuess)
+		fmt.Printf("  %.2f bits, best possible %.2f (%s)\n", a.bits, a.bestBits, a.bitsGuess)
+
+		if !safe || a.guess == record.Answer {
+			continue
+		}
+
+		if !sureWinAfter(record.Guesses[:i+1], record.Answer, TotalGuesses-i-1, record.HardMode, pool) {
+			safe = false
+
+			fmt.Println("  not worst-case safe, some answers it left might not be found in time")
+		}
+	}
+
+	fmt.Println()
+
+	if safe {
+		fmt.Println("Worst-case safe: whatever the answer, every guess left a sure win.")
+	} else {
+		fmt.Println("Not worst-case safe: with worse luck the game could have run out of guesses.")
+	}
+
+	return nil
+}
+
+// analyzeGuess scores a guess against the candidates the earlier guesses
+// left, and finds the best guesses by worst case and by information that
+// could have been made instead.
+func analyzeGuess(earlier []string, guess string, answer string, hardMode bool, pool []string) guessAnalysis {
+	candidates := remainingCandidates(earlier, answer, wordList)
+
+	if hardMode {
+		pool = hardModeGuesses(pool, candidates, buildConstraints(earlier, answer))
+	}
+
+	set := newCandidateSet(candidates)
+	counts := make([]int, PatternCount)
+
+	set.countPatterns(guess, counts)
+
+	a := guessAnalysis{
+		guess:    guess,
+		possible: len(candidates),
+		worst:    int(minimaxScore(counts, len(candidates))),
+		bits:     -entropyScore(counts, len(candidates)),
+	}
+
+	// the strategies just pick a candidate once one or two are left, so the
+	// pool is scored directly instead
+	state := &SolverState{Candidates: candidates, Pool: pool}
+
+	a.worstGuess = bestGuess(state, func(g string) float64 {
+		set.countPatterns(g, counts)
+		return minimaxScore(counts, len(candidates))
+	})
+
+	a.bitsGuess = bestGuess(state, func(g string) float64 {
+		set.countPatterns(g, counts)
+		return entropyScore(counts, len(candidates))
+	})
+
+	set.countPatterns(a.worstGuess, counts)
+	a.bestWorst = int(minimaxScore(counts, len(candidates)))
+
+	set.countPatterns(a.bitsGuess, counts)
+	a.bestBits = -entropyScore(counts, len(candidates))
+
+	return a
+}
+
+// sureWinAfter checks that every answer the guesses leave possible could be
+// found in the guesses left.
+func sureWinAfter(guesses []string, answer string, left int, hardMode bool, pool []string) bool {
+	candidates := remainingCandidates(guesses, answer, wordList)
+
+	return sureWin(guesses, candidates, left, hardMode, pool)
+}
+
+// sureWin reports whether the candidates the guesses left can always be
+// solved within the guesses left, by making the guess with the smallest worst
+// case each time. A cleverer line could find wins this misses, so false only
+// means no sure win was found.
+func sureWin(guesses []string, candidates []string, left int, hardMode bool, pool []string) bool {
+	switch {
+	case len(candidates) <= 1:
+		return left >= len(candidates)
+	case len(candidates) <= left:
+		// guess them one at a time
+		return true
+	case left <= 1:
+		return false
+	}
+
+	// every candidate would have scored the guesses the same, so any of
+	// them gives the same constraints
+	if hardMode {
+		pool = hardModeGuesses(pool, candidates, buildConstraints(guesses, candidates[0]))
+	}
+
+	set := newCandidateSet(candidates)
+	counts := make([]int, PatternCount)
+
+	guess := bestGuess(&SolverState{Candidates: candidates, Pool: pool}, func(g string) float64 {
+		set.countPatterns(g, counts)
+		counts[PatternSolved] = 0
+
+		return minimaxScore(counts, len(candidates))
+	})
+
+	buckets := map[Pattern][]string{}
+	row := evalCache.Row(guess)
+
+	for i, candidate := range candidates {
+		p := set.pattern(row, guess, i)
+		if p != PatternSolved {
+			buckets[p] = append(buckets[p], candidate)
+		}
+	}
+
+	for _, bucket := range buckets {
+		if !sureWin(append(guesses[:len(guesses):len(guesses)], guess), bucket, left-1, hardMode, pool) {
+			return false
+		}
+	}
+
+	return true
+}
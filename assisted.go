@@ -0,0 +1,54 @@
+package main
+
+import "fmt"
+
+// KeySolverUsed toggles whether the current game is marked as solver assisted.
+const KeySolverUsed = '#'
+
+// assisted is set when the player owns up to using an outside solver during
+// the current game.
+var assisted bool
+
+// AssistedStats tallies solver assisted games apart from the regular stats so
+// they don't pad the win distribution or the streak.
+type AssistedStats struct {
+	Games int   `json:"games"`
+	Wins  []int `json:"wins"`
+}
+
+// countAssisted moves a finished game out of the regular stats, where it was
+// counted on its first guess, and into the assisted bucket.
+func (gs *GameStats) countAssisted(hardMode bool, win bool, guesses int) {
+	if hardMode {
+		gs.TotalHardGames--
+	} else {
+		gs.TotalGames--
+	}
+
+	if len(gs.Assisted.Wins) != TotalGuesses {
+		gs.Assisted.Wins = make([]int, TotalGuesses)
+	}
+
+	gs.Assisted.Games++
+
+	if win && guesses > 0 && guesses <= TotalGuesses {
+		gs.Assisted.Wins[guesses-1]++
+	}
+}
+
+// toggleAssisted flips the solver assisted marker, keeping it with today's
+// daily so it survives the daily being resumed.
+func (gs *GameStats) toggleAssisted() string {
+	assisted = !assisted
+
+	if progress := gs.PendingDailies[args.Group]; progress != nil {
+		progress.Assisted = assisted
+		_ = gs.save()
+	}
+
+	if assisted {
+		return fmt.Sprintf("marked as solver assisted, %c to undo", KeySolverUsed)
+	}
+
+	return "no longer marked as solver assisted"
+}
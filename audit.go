@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+type AuditCommand struct {
+	Answers bool `long:"answers" description:"Also show the answer for each date, spoilers included"`
+
+	Args struct {
+		Dates []string `positional-arg-name:"date" description:"Days to look up, as YYYY-MM-DD"`
+	} `positional-args:"yes"`
+}
+
+func (cmd *AuditCommand) Execute(_ []string) error {
+	gamestats := loadGameStats()
+
+	parseWordLists()
+
+	day := int(time.Since(dailyEpoch).Hours() / 24)
+	cycle := day / len(wordList)
+	used := day % len(wordList)
+	wraps := dailyEpoch.AddDate(0, 0, (cycle+1)*len(wordList))
+
+	rotation := "in order"
+	if gamestats.DailyRotation == RotationShuffled {
+		rotation = "shuffled"
+	}
+
+	fmt.Print("Answer Rotation\n\n")
+	fmt.Printf("      Answers: %d\n", len(wordList))
+	fmt.Printf("     Rotation: %s\n", rotation)
+	fmt.Printf("  Today's Day: %d (puzzle %d)\n", day, day+gamestats.PuzzleNumberOffset)
+	fmt.Printf("        Index: %d\n", gamestats.dailyIndex(day))
+	fmt.Printf("        Cycle: %d, %d%% used\n", cycle+1, used*100/len(wordList))
+	fmt.Printf("        Wraps: %s, in %d days\n", wraps.Format("2006-01-02"), len(wordList)-used)
+
+	if warning := rotationWarning(len(wordList), gamestats.DailyRotation); warning != "" {
+		fmt.Printf("\nNote: %s\n", warning)
+	}
+
+	if len(cmd.Args.Dates) == 0 {
+		return nil
+	}
+
+	fmt.Println()
+
+	for _, date := range cmd.Args.Dates {
+		t, err := time.Parse("2006-01-02", date)
+		if err != nil {
+			return fmt.Errorf("invalid date %q, expected YYYY-MM-DD", date)
+		}
+
+		d := int(t.Sub(dailyEpoch).Hours() / 24)
+		if d < 0 {
+			fmt.Printf("%s: before the first daily\n", date)
+			continue
+		}
+
+		fmt.Printf("%s: day %d, index %d", date, d, gamestats.dailyIndex(d))
+
+		if cmd.Answers {
+			fmt.Printf(", %s", wordList[gamestats.dailyIndex(d)])
+		}
+
+		fmt.Println()
+	}
+
+	return nil
+}
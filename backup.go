@@ -0,0 +1,158 @@
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"time"
+)
+
+// BackupStatsName is the name of the stats file inside a backup archive.
+const BackupStatsName = "wordle/stats.json"
+
+type BackupCommand struct {
+	Create  BackupCreateCommand  `command:"create" description:"Write stats, history, and config to a .tar.gz archive"`
+	Restore BackupRestoreCommand `command:"restore" description:"Replace stats, history, and config with the ones in an archive"`
+}
+
+type BackupCreateCommand struct {
+	Args struct {
+		File string `positional-arg-name:"file" required:"yes" description:"Archive to write, e.g. backup.tar.gz"`
+	} `positional-args:"yes"`
+}
+
+type BackupRestoreCommand struct {
+	Args struct {
+		File string `positional-arg-name:"file" required:"yes" description:"Archive made by backup create"`
+	} `positional-args:"yes"`
+}
+
+// Execute archives the stats file. Everything the game keeps, history and
+// config included, lives in that one file.
+func (cmd *BackupCreateCommand) Execute(_ []string) error {
+	savePath, _, err := statsPath()
+	if err != nil {
+		return err
+	}
+
+	raw, err := ioutil.ReadFile(savePath)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(cmd.Args.File, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	zw := gzip.NewWriter(f)
+	tw := tar.NewWriter(zw)
+
+	err = tw.WriteHeader(&tar.Header{
+		Name:    BackupStatsName,
+		Mode:    0644,
+		Size:    int64(len(raw)),
+		ModTime: time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = tw.Write(raw)
+	if err != nil {
+		return err
+	}
+
+	err = tw.Close()
+	if err != nil {
+		return err
+	}
+
+	err = zw.Close()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Backed up %s to %s\n", savePath, cmd.Args.File)
+
+	return nil
+}
+
+// Execute restores the stats file from an archive. Whatever was there before
+// is kept next to it with a .bak extension, in case the wrong archive was
+// picked.
+func (cmd *BackupRestoreCommand) Execute(_ []string) error {
+	raw, err := readBackup(cmd.Args.File)
+	if err != nil {
+		return err
+	}
+
+	// make sure it's something the game can load before replacing anything
+	err = json.NewDecoder(bytes.NewReader(raw)).Decode(&GameStats{})
+	if err != nil {
+		return fmt.Errorf("%s doesn't hold valid stats: %w", cmd.Args.File, err)
+	}
+
+	savePath, _, err := statsPath()
+	if err != nil {
+		return err
+	}
+
+	old, err := ioutil.ReadFile(savePath)
+	if err == nil {
+		err = ioutil.WriteFile(savePath+".bak", old, 0644)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Kept the old stats in %s.bak\n", savePath)
+	}
+
+	err = ioutil.WriteFile(savePath, raw, 0644)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Restored %s from %s\n", savePath, cmd.Args.File)
+
+	return nil
+}
+
+// readBackup pulls the stats file out of a backup archive.
+func readBackup(file string) ([]byte, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s isn't a wordle backup: %w", file, err)
+	}
+	defer zr.Close()
+
+	tr := tar.NewReader(zr)
+
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%s isn't a wordle backup, there's no %s in it", file, BackupStatsName)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if header.Name == BackupStatsName {
+			return ioutil.ReadAll(tr)
+		}
+	}
+}
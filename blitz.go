@@ -0,0 +1,315 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-tty"
+)
+
+const (
+	BlitzPuzzles  = 5
+	BlitzDuration = 5 * time.Minute
+)
+
+type BlitzStats struct {
+	Games      int   `json:"games"`
+	Solves     []int `json:"solves"`
+	BestScore  int   `json:"best_score"`
+	TotalScore int   `json:"total_score"`
+}
+
+type BlitzCommand struct {
+	Stats bool `short:"s" long:"stats" description:"Print blitz stats without playing"`
+}
+
+// blitzOutcome is how a single puzzle of a blitz ended.
+type blitzOutcome int
+
+const (
+	blitzSolved blitzOutcome = iota
+	blitzFailed
+	blitzTimeUp
+	blitzQuit
+)
+
+// blitzRun is the shared state of a blitz while its puzzles are played.
+type blitzRun struct {
+	stat      *Screen
+	keys      <-chan rune
+	interrupt <-chan os.Signal
+	ticker    *time.Ticker
+	deadline  time.Time
+	puzzle    int
+}
+
+func (cmd *BlitzCommand) Execute(_ []string) error {
+	gamestats := loadGameStats()
+	gamestats.applyTheme()
+
+	if cmd.Stats {
+		gamestats.Blitz.print()
+		return nil
+	}
+
+	parseWordLists()
+
+	rand.Seed(time.Now().UnixNano())
+
+	words := make([]string, BlitzPuzzles)
+	for i, j := range rand.Perm(len(wordList))[:BlitzPuzzles] {
+		words[i] = wordList[j]
+	}
+
+	fmt.Printf(" Blitz: %d words, %s\n", BlitzPuzzles, formatClock(BlitzDuration))
+
+	ty, err := tty.Open()
+	if err != nil {
+		return err
+	}
+	defer ty.Close()
+
+	anchorBoard()
+
+	stat, err := newScreen(TotalGuesses + 1 + KeyboardRows + PanelLines) // +1 for "status" line
+	if err != nil {
+		return err
+	}
+	defer stat.Finish()
+
+	keys := make(chan rune)
+
+	go func() {
+		for {
+			pressed, err := ty.ReadRune()
+			if err != nil {
+				close(keys)
+				return
+			}
+
+			keys <- pressed
+		}
+	}()
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+
+	run := &blitzRun{
+		stat:      stat,
+		keys:      keys,
+		interrupt: interrupt,
+		ticker:    time.NewTicker(time.Second),
+		deadline:  time.Now().Add(BlitzDuration),
+	}
+	defer run.ticker.Stop()
+
+	results := make([]string, 0, BlitzPuzzles)
+	solves := 0
+	session := newSession()
+
+	for run.puzzle = 0; run.puzzle < BlitzPuzzles; run.puzzle++ {
+		word = words[run.puzzle]
+		resetGame()
+
+		outcome := run.play()
+		if outcome == blitzQuit {
+			stat.Finish()
+			fmt.Print("Blitz abandoned\n\n")
+			session.print()
+
+			return nil
+		}
+
+		switch outcome {
+		case blitzSolved:
+			session.record(true, currentGuess+1)
+		case blitzFailed:
+			session.record(false, currentGuess)
+		case blitzTimeUp:
+			if currentGuess > 0 {
+				session.record(false, currentGuess)
+			}
+		}
+
+		if outcome == blitzSolved {
+			solves++
+			results = append(results, string(EmojiLocated))
+		} else {
+			results = append(results, string(EmojiNotInWord))
+		}
+
+		if outcome == blitzTimeUp {
+			break
+		}
+	}
+
+	stat.Finish()
+
+	left := time.Until(run.deadline)
+	if left < 0 {
+		left = 0
+	}
+
+	// time left over only counts for a clean sweep, failing fast isn't a skill
+	score := solves * 100
+	if solves == BlitzPuzzles {
+		score += int(left.Seconds())
+	}
+
+	gamestats.Blitz.record(solves, score)
+	_ = gamestats.save()
+
+	fmt.Printf("Solved %d of %d with %s left, score %d\n\n", solves, BlitzPuzzles, formatClock(left), score)
+
+	session.print()
+
+	gamestats.Blitz.print()
+
+	if !gamestats.useEmoji() {
+		return nil
+	}
+
+	for len(results) < BlitzPuzzles {
+		results = append(results, "⬜")
+	}
+
+	fmt.Println()
+	fmt.Println(gamestats.shareLine(fmt.Sprintf("Wordle Blitz %d/%d ⏱️ %s", solves, BlitzPuzzles, formatClock(left))))
+	fmt.Println()
+	fmt.Println(gamestats.shareLine(strings.Join(results, "")))
+
+	return nil
+}
+
+// play runs a single puzzle of the blitz until it's solved, failed, or the
+// clock runs out.
+func (run *blitzRun) play() blitzOutcome {
+	for i := 0; i < TotalGuesses; i++ {
+		_, _ = run.stat.WriteString(guessLine(i), "     _ _ _ _ _")
+	}
+
+	_, _ = run.stat.WriteString(guessLine(0), formatGuess("", false))
+	printKeyboard(run.stat)
+	run.printClock()
+
+	guess := ""
+
+	for {
+		run.stat.Flush()
+
+		select {
+		case <-run.interrupt:
+			return blitzQuit
+		case <-run.ticker.C:
+			if !time.Now().Before(run.deadline) {
+				return blitzTimeUp
+			}
+
+			run.printClock()
+		case pressed, ok := <-run.keys:
+			if !ok {
+				return blitzQuit
+			}
+
+			run.stat.Begin()
+			pressed = translateInput(pressed)
+
+			if (pressed == KeyCodeMacBackspace || pressed == KeyCodeWinBackspace) && len(guess) != 0 {
+				guess = guess[:len(guess)-1]
+				_, _ = run.stat.WriteString(guessLine(currentGuess), formatGuess(guess, false))
+
+				continue
+			}
+
+			if pressed == KeyCodeEnter && len(guess) == WordLength {
+				if !isWord(guess) {
+					_, _ = run.stat.WriteString(guessLine(currentGuess), formatGuess(guess, false)+" (must be a word)")
+					continue
+				}
+
+				_, _ = run.stat.WriteString(guessLine(currentGuess), formatGuess(guess, true))
+				printKeyboard(run.stat)
+
+				if guess == word {
+					return blitzSolved
+				}
+
+				currentGuess++
+				guess = ""
+
+				if currentGuess == TotalGuesses {
+					return blitzFailed
+				}
+
+				advanceGuessRow(run.stat)
+				_, _ = run.stat.WriteString(guessLine(currentGuess), formatGuess(guess, false))
+
+				continue
+			}
+
+			if len(guess) < WordLength && isGuessLetter(pressed) {
+				guess += string(pressed)
+				_, _ = run.stat.WriteString(guessLine(currentGuess), formatGuess(guess, false))
+			}
+		}
+	}
+}
+
+func (run *blitzRun) printClock() {
+	left := time.Until(run.deadline)
+	if left < 0 {
+		left = 0
+	}
+
+	_, _ = run.stat.WriteString(statusLine(), fmt.Sprintf("Word %d/%d  %s left", run.puzzle+1, BlitzPuzzles, formatClock(left)))
+}
+
+func (bs *BlitzStats) record(solves int, score int) {
+	if len(bs.Solves) != BlitzPuzzles+1 {
+		bs.Solves = make([]int, BlitzPuzzles+1)
+	}
+
+	bs.Games++
+	bs.Solves[solves]++
+	bs.TotalScore += score
+
+	if score > bs.BestScore {
+		bs.BestScore = score
+	}
+}
+
+func (bs *BlitzStats) print() {
+	fmt.Print("Blitz Stats\n\n")
+	fmt.Printf("  Total Games: %d\n", bs.Games)
+
+	if bs.Games == 0 {
+		return
+	}
+
+	fmt.Printf("   Best Score: %d\n", bs.BestScore)
+	fmt.Printf("Average Score: %d\n", bs.TotalScore/bs.Games)
+	fmt.Println()
+	fmt.Print("Words Solved:\n\n")
+
+	max := 0
+	for _, count := range bs.Solves {
+		if count > max {
+			max = count
+		}
+	}
+
+	for i, count := range bs.Solves {
+		fmt.Printf("%d: %d %s\n", i, count, histogramBar(count, max))
+	}
+}
+
+// formatClock formats a duration as minutes and seconds, e.g. 4:05.
+func formatClock(d time.Duration) string {
+	seconds := int(d.Round(time.Second).Seconds())
+
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+}
@@ -0,0 +1,33 @@
+package main
+
+// brailleLetters are the braille cells for A through Z.
+var brailleLetters = []rune("⠁⠃⠉⠙⠑⠋⠛⠓⠊⠚⠅⠇⠍⠝⠕⠏⠟⠗⠎⠞⠥⠧⠺⠭⠽⠵")
+
+// The bottom row of 8 dot braille carries the hint on top of the letter.
+const (
+	brailleDot7 = 0x40
+	brailleDot8 = 0x80
+)
+
+// brailleFeedback spells out a scored guess as 8 dot braille for refreshable
+// braille displays. Each cell is the letter with its hint in the bottom row:
+// dots 7 and 8 for located, dot 8 alone for somewhere else, and neither for
+// not in the word.
+func brailleFeedback(guess string, hints []KeyHint) string {
+	cells := make([]rune, len(guess))
+
+	for i := range guess {
+		cell := brailleLetters[guess[i]-'A']
+
+		switch hints[i] {
+		case KeyHintLocated:
+			cell |= brailleDot7 | brailleDot8
+		case KeyHintSomewhere:
+			cell |= brailleDot8
+		}
+
+		cells[i] = cell
+	}
+
+	return string(cells)
+}
@@ -0,0 +1,331 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ChatLeaders is how many players the leaderboard of a chat shows.
+const ChatLeaders = 5
+
+// ChatStats are the stats of a chat playing through one of the bots. A chat
+// plays the same daily as the terminal game, everyone in it together.
+type ChatStats struct {
+	Day        int            `json:"day"`
+	Guesses    []string       `json:"guesses"`
+	Games      int            `json:"games"`
+	Wins       []int          `json:"wins"`
+	Streak     int            `json:"streak"`
+	BestStreak int            `json:"best_streak"`
+	Winners    map[string]int `json:"winners"`
+}
+
+// chat returns the stats of a chat, set up for today's daily. Chats are keyed
+// by the service they're on, e.g. telegram:1234.
+func (gs *GameStats) chat(name string) *ChatStats {
+	if gs.Chats == nil {
+		gs.Chats = map[string]*ChatStats{}
+	}
+
+	cs, ok := gs.Chats[name]
+	if !ok {
+		cs = &ChatStats{Wins: make([]int, TotalGuesses)}
+		gs.Chats[name] = cs
+	}
+
+	today := int(time.Since(dailyEpoch).Hours() / 24)
+	if cs.Day != today {
+		// an unfinished or skipped daily breaks the streak
+		if cs.Day != today-1 || !cs.finished(gs) {
+			cs.Streak = 0
+		}
+
+		cs.Day = today
+		cs.Guesses = nil
+	}
+
+	return cs
+}
+
+// chatStatsPath is where the bots keep the stats of their chats, next to the
+// stats file but apart from it, so a bot never writes over games played in
+// the terminal while it was running.
+func chatStatsPath() (string, error) {
+	savePath, _, err := statsPath()
+	if err != nil {
+		return "", err
+	}
+
+	return savePath + ".chats", nil
+}
+
+// readChats reads the stats of every chat. ok is false when there's no chat
+// stats file yet.
+func readChats() (chats map[string]*ChatStats, ok bool, err error) {
+	path, err := chatStatsPath()
+	if err != nil {
+		return nil, false, err
+	}
+
+	raw, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, false, nil
+	}
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	chats = map[string]*ChatStats{}
+
+	err = json.Unmarshal(raw, &chats)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return chats, true, nil
+}
+
+// playChat answers a message like chatMessage, starting from the chat stats
+// on disk and saving the chat back when there's a reply. Only that chat is
+// written, the others are kept as they are on disk so several bots can run
+// at once. The reply is returned even when the stats can't be read or saved.
+func (gs *GameStats) playChat(name string, player string, text string, prefix string) (string, error) {
+	if args.NoStats {
+		return gs.chatMessage(name, player, text, prefix), nil
+	}
+
+	// until the bots have saved once, the chats the stats file used to keep
+	// are carried over
+	chats, ok, err := readChats()
+	if ok {
+		gs.Chats = chats
+	}
+
+	reply := gs.chatMessage(name, player, text, prefix)
+	if reply == "" || err != nil {
+		return reply, err
+	}
+
+	return reply, gs.saveChat(name)
+}
+
+// saveChat writes one chat's stats into the chat stats file.
+func (gs *GameStats) saveChat(name string) error {
+	chats, ok, err := readChats()
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		chats = gs.Chats
+	}
+
+	chats[name] = gs.Chats[name]
+
+	raw, err := json.Marshal(chats)
+	if err != nil {
+		return err
+	}
+
+	path, err := chatStatsPath()
+	if err != nil {
+		return err
+	}
+
+	// write to the side and move into place so another bot never reads half a file
+	tmp := path + ".tmp"
+
+	err = ioutil.WriteFile(tmp, append(raw, '\n'), 0644)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, path)
+}
+
+// chatMessage answers a message a player sent to one of the bots, or returns
+// an empty string if there's nothing to say. Commands start with whatever
+// prefix the chat service uses. Anything else that looks like a word is taken
+// as a guess so chatter in a group is left alone.
+func (gs *GameStats) chatMessage(name string, player string, text string, prefix string) string {
+	text = strings.TrimSpace(text)
+
+	if !strings.HasPrefix(text, prefix) {
+		guess := strings.ToUpper(text)
+		if !isFiveLetters(guess) {
+			return ""
+		}
+
+		return gs.chatGuess(name, player, guess)
+	}
+
+	fields := strings.Fields(text[len(prefix):])
+	if len(fields) == 0 {
+		return ""
+	}
+
+	// telegram addresses commands in groups like /stats@SomeBot
+	command := strings.ToLower(fields[0])
+	if i := strings.IndexByte(command, '@'); i >= 0 {
+		command = command[:i]
+	}
+
+	switch command {
+	case "guess":
+		if len(fields) < 2 {
+			return fmt.Sprintf("Usage: %sguess WORD", prefix)
+		}
+
+		return gs.chatGuess(name, player, strings.ToUpper(fields[1]))
+	case "board":
+		cs := gs.chat(name)
+		if len(cs.Guesses) == 0 {
+			return "No guesses yet today"
+		}
+
+		return cs.board(gs)
+	case "stats":
+		return gs.chat(name).summary()
+	case "top":
+		return gs.chat(name).leaderboard()
+	case "start", "help":
+		return chatHelp(prefix)
+	}
+
+	return ""
+}
+
+// chatGuess plays a guess on a chat's daily and replies with the board. The
+// player who makes the winning guess gets the credit on the leaderboard.
+func (gs *GameStats) chatGuess(name string, player string, guess string) string {
+	cs := gs.chat(name)
+
+	if cs.finished(gs) {
+		return "Today's word is done, a new one comes tomorrow"
+	}
+
+	if rejection := wordRejection(guess); rejection != nil {
+		return fmt.Sprintf("%s: %s", guess, rejection)
+	}
+
+	cs.Guesses = append(cs.Guesses, guess)
+	reply := cs.board(gs)
+
+	switch {
+	case guess == cs.answer(gs):
+		cs.record(true)
+
+		solver := ""
+		if player != "" {
+			if cs.Winners == nil {
+				cs.Winners = map[string]int{}
+			}
+
+			cs.Winners[player]++
+			solver = " by " + player
+		}
+
+		reply += fmt.Sprintf("\n\nSolved%s! Streak: %d", solver, cs.Streak)
+	case len(cs.Guesses) == TotalGuesses:
+		cs.record(false)
+		reply += fmt.Sprintf("\n\nThe word was %s", cs.answer(gs))
+	}
+
+	return reply
+}
+
+// answer is the chat's daily, the same word the daily has that day.
+func (cs *ChatStats) answer(gs *GameStats) string {
+	return wordList[gs.dailyIndex(cs.Day)]
+}
+
+func (cs *ChatStats) finished(gs *GameStats) bool {
+	guesses := len(cs.Guesses)
+
+	return guesses == TotalGuesses || guesses > 0 && cs.Guesses[guesses-1] == cs.answer(gs)
+}
+
+// board is the chat's daily so far as share emoji.
+func (cs *ChatStats) board(gs *GameStats) string {
+	turn := fmt.Sprint(len(cs.Guesses))
+	if cs.finished(gs) && cs.Guesses[len(cs.Guesses)-1] != cs.answer(gs) {
+		turn = "X"
+	}
+
+	rows := []string{fmt.Sprintf("Wordle %d %s/%d", cs.Day+gs.PuzzleNumberOffset, turn, TotalGuesses), ""}
+	for _, guess := range cs.Guesses {
+		rows = append(rows, hintEmoji(scoreGuess(guess, cs.answer(gs)))+" "+guess)
+	}
+
+	return strings.Join(rows, "\n")
+}
+
+func (cs *ChatStats) record(win bool) {
+	cs.Games++
+
+	if !win {
+		cs.Streak = 0
+		return
+	}
+
+	cs.Wins[len(cs.Guesses)-1]++
+	cs.Streak++
+
+	if cs.Streak > cs.BestStreak {
+		cs.BestStreak = cs.Streak
+	}
+}
+
+func (cs *ChatStats) summary() string {
+	if cs.Games == 0 {
+		return "No games finished yet"
+	}
+
+	wins := 0
+	for _, count := range cs.Wins {
+		wins += count
+	}
+
+	return fmt.Sprintf("Played: %d, Win %%: %d, Streak: %d, Best Streak: %d", cs.Games, wins*100/cs.Games, cs.Streak, cs.BestStreak)
+}
+
+// leaderboard lists who made the most winning guesses in a chat.
+func (cs *ChatStats) leaderboard() string {
+	if len(cs.Winners) == 0 {
+		return "Nobody has solved one yet"
+	}
+
+	players := make([]string, 0, len(cs.Winners))
+	for player := range cs.Winners {
+		players = append(players, player)
+	}
+
+	sort.Slice(players, func(i, j int) bool {
+		if cs.Winners[players[i]] != cs.Winners[players[j]] {
+			return cs.Winners[players[i]] > cs.Winners[players[j]]
+		}
+
+		return players[i] < players[j]
+	})
+
+	if len(players) > ChatLeaders {
+		players = players[:ChatLeaders]
+	}
+
+	lines := make([]string, len(players))
+	for i, player := range players {
+		lines[i] = fmt.Sprintf("%d. %s: %d", i+1, player, cs.Winners[player])
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func chatHelp(prefix string) string {
+	return fmt.Sprintf("Send a five letter word to guess today's Wordle. %sboard shows the guesses so far, %sstats shows this chat's stats, and %stop shows who has solved the most.", prefix, prefix, prefix)
+}
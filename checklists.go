@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+)
+
+type CheckListsCommand struct {
+	Args struct {
+		Answers string `positional-arg-name:"answers" description:"Answer list to check instead of the built in good_words.txt"`
+		Allowed string `positional-arg-name:"allowed" description:"Allowed guess list to check instead of the built in bad_words.txt"`
+	} `positional-args:"yes"`
+}
+
+func (cmd *CheckListsCommand) Execute(_ []string) error {
+	answersName, answersRaw := "good_words.txt", rawGoodWordList
+	allowedName, allowedRaw := "bad_words.txt", rawBadWordList
+
+	if cmd.Args.Answers != "" {
+		raw, err := ioutil.ReadFile(cmd.Args.Answers)
+		if err != nil {
+			return err
+		}
+
+		answersName, answersRaw = cmd.Args.Answers, string(raw)
+	}
+
+	if cmd.Args.Allowed != "" {
+		raw, err := ioutil.ReadFile(cmd.Args.Allowed)
+		if err != nil {
+			return err
+		}
+
+		allowedName, allowedRaw = cmd.Args.Allowed, string(raw)
+	}
+
+	answers, problems := normalizeWordList(answersName, answersRaw)
+
+	allowed, more := normalizeWordList(allowedName, allowedRaw)
+	problems = append(problems, more...)
+	problems = append(problems, checkWordLists(answers, allowedName, allowedRaw)...)
+
+	for _, problem := range problems {
+		fmt.Println(problem)
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("found %d problem(s)", len(problems))
+	}
+
+	fmt.Printf("%d answers and %d allowed guesses look good\n", len(answers), len(allowed))
+
+	if warning := rotationWarning(len(answers), ""); warning != "" {
+		fmt.Printf("Note: %s\n", warning)
+	}
+
+	return nil
+}
+
+// checkWordLists checks what a pair of word lists should hold to beyond what's
+// needed to load them: no answer is also on the allowed list, and the allowed
+// list is sorted. Entries normalizeWordList would skip are left to it.
+func checkWordLists(answers []string, allowedName string, allowedRaw string) []string {
+	problems := []string{}
+
+	isAnswer := map[string]bool{}
+	for _, w := range answers {
+		isAnswer[w] = true
+	}
+
+	previous := ""
+
+	for i, line := range strings.Split(strings.TrimPrefix(allowedRaw, "\ufeff"), "\n") {
+		w := strings.ToUpper(strings.TrimSpace(line))
+		if !isFiveLetters(w) {
+			continue
+		}
+
+		if isAnswer[w] {
+			problems = append(problems, fmt.Sprintf("%s:%d: %s is also an answer", allowedName, i+1, w))
+		}
+
+		if w < previous {
+			problems = append(problems, fmt.Sprintf("%s:%d: %s is out of order, it comes after %s", allowedName, i+1, w, previous))
+		}
+
+		if w > previous {
+			previous = w
+		}
+	}
+
+	return problems
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type HistoryCommand struct {
+	Compact HistoryCompactCommand `command:"compact" description:"Fold old games into monthly summaries to keep the stats file small"`
+}
+
+type HistoryCompactCommand struct {
+	Keep string `long:"keep" default:"2y" description:"How much history to keep game by game, e.g. 2y, 6m, 8w, or 90d" value-name:"AGE"`
+}
+
+// HistorySummary stands in for the games of one month, mode, group, and set
+// of flags, hard or not, after they've been compacted. It keeps what the
+// stats average over the history so lifetime averages don't change, and the
+// puzzle numbers of the dailies so they aren't imported again, but not the
+// guesses themselves. Win counts aren't kept here, the stats count them on
+// their own.
+type HistorySummary struct {
+	Month           string   `json:"month"`
+	Mode            string   `json:"mode,omitempty"`
+	HardMode        bool     `json:"hard_mode"`
+	Group           string   `json:"group,omitempty"`
+	Flags           []string `json:"flags,omitempty"`
+	Numbers         []int    `json:"numbers,omitempty"`
+	Rarity          int      `json:"rarity"`
+	RarityGames     int      `json:"rarity_games"`
+	Luck            int      `json:"luck"`
+	LuckGames       int      `json:"luck_games"`
+	Difficulty      int      `json:"difficulty"`
+	DifficultyGames int      `json:"difficulty_games"`
+}
+
+func (cmd *HistoryCompactCommand) Execute(_ []string) error {
+	cutoff, err := keepCutoff(cmd.Keep, time.Now())
+	if err != nil {
+		return err
+	}
+
+	gamestats := loadGameStats()
+
+	compacted := gamestats.compactHistory(cutoff)
+	if compacted == 0 {
+		fmt.Printf("Nothing played before %s, no games compacted\n", cutoff.Format("2006-01-02"))
+		return nil
+	}
+
+	err = gamestats.save()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Compacted %d games played before %s into monthly summaries, %d games kept\n",
+		compacted, cutoff.Format("2006-01-02"), len(gamestats.History))
+
+	return nil
+}
+
+// keepCutoff works out the oldest date to keep from an age like 2y, 6m, 8w, or
+// 90d.
+func keepCutoff(keep string, now time.Time) (time.Time, error) {
+	keep = strings.ToLower(strings.TrimSpace(keep))
+	if len(keep) < 2 {
+		return now, fmt.Errorf("invalid age %q, expected something like 2y, 6m, 8w, or 90d", keep)
+	}
+
+	n, err := strconv.Atoi(keep[:len(keep)-1])
+	if err != nil || n < 0 {
+		return now, fmt.Errorf("invalid age %q, expected something like 2y, 6m, 8w, or 90d", keep)
+	}
+
+	switch keep[len(keep)-1] {
+	case 'y':
+		return now.AddDate(-n, 0, 0), nil
+	case 'm':
+		return now.AddDate(0, -n, 0), nil
+	case 'w':
+		return now.AddDate(0, 0, -7*n), nil
+	case 'd':
+		return now.AddDate(0, 0, -n), nil
+	}
+
+	return now, fmt.Errorf("invalid age %q, expected something like 2y, 6m, 8w, or 90d", keep)
+}
+
+// compactHistory folds the games played before the cutoff into summaries and
+// returns how many were folded. The win counts and streaks are kept apart
+// from the history so they're untouched.
+func (gs *GameStats) compactHistory(cutoff time.Time) int {
+	kept := []GameRecord{}
+	compacted := 0
+
+	for _, game := range gs.History {
+		if !game.Date.Before(cutoff) {
+			kept = append(kept, game)
+			continue
+		}
+
+		summary := gs.historySummary(game.Date.Format("2006-01"), &game)
+
+		if number := gs.dailyNumber(&game); number != 0 {
+			summary.Numbers = append(summary.Numbers, number)
+		}
+
+		if game.Answer != "" {
+			summary.Rarity += answerRarity(game.Answer)
+			summary.RarityGames++
+		}
+
+		if game.Luck != nil {
+			summary.Luck += *game.Luck
+			summary.LuckGames++
+		}
+
+		if game.Difficulty != nil {
+			summary.Difficulty += *game.Difficulty
+			summary.DifficultyGames++
+		}
+
+		compacted++
+	}
+
+	gs.History = kept
+
+	return compacted
+}
+
+// historySummary finds the summary a game is folded into, adding one if it's
+// the first game of its kind that month.
+func (gs *GameStats) historySummary(month string, game *GameRecord) *HistorySummary {
+	flags := strings.Join(game.Flags, ",")
+
+	for i := range gs.Compacted {
+		summary := &gs.Compacted[i]
+		if summary.Month == month && summary.Mode == game.Mode && summary.HardMode == game.HardMode &&
+			summary.Group == game.Group && strings.Join(summary.Flags, ",") == flags {
+			return summary
+		}
+	}
+
+	gs.Compacted = append(gs.Compacted, HistorySummary{
+		Month:    month,
+		Mode:     game.Mode,
+		HardMode: game.HardMode,
+		Group:    game.Group,
+		Flags:    game.Flags,
+	})
+
+	return &gs.Compacted[len(gs.Compacted)-1]
+}
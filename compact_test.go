@@ -0,0 +1,91 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestKeepCutoff(t *testing.T) {
+	now := time.Date(2024, time.March, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		keep string
+		want time.Time
+		err  bool
+	}{
+		{"2y", time.Date(2022, time.March, 15, 12, 0, 0, 0, time.UTC), false},
+		{"6m", time.Date(2023, time.September, 15, 12, 0, 0, 0, time.UTC), false},
+		{"8w", time.Date(2024, time.January, 19, 12, 0, 0, 0, time.UTC), false},
+		{" 90D ", time.Date(2023, time.December, 16, 12, 0, 0, 0, time.UTC), false},
+		{"0d", now, false},
+		{"y", now, true},
+		{"2", now, true},
+		{"-1y", now, true},
+		{"2h", now, true},
+		{"twoy", now, true},
+	}
+
+	for _, tt := range tests {
+		got, err := keepCutoff(tt.keep, now)
+
+		if (err != nil) != tt.err {
+			t.Errorf("keepCutoff(%q) error = %v, want error %v", tt.keep, err, tt.err)
+			continue
+		}
+
+		if !got.Equal(tt.want) {
+			t.Errorf("keepCutoff(%q) = %v, want %v", tt.keep, got, tt.want)
+		}
+	}
+}
+
+func TestCompactHistory(t *testing.T) {
+	cutoff := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	luck := func(n int) *int { return &n }
+
+	gs := GameStats{History: []GameRecord{
+		{Date: time.Date(2024, time.January, 3, 0, 0, 0, 0, time.UTC), Mode: GameModeDaily, Number: 928, Luck: luck(40)},
+		{Date: time.Date(2024, time.January, 4, 0, 0, 0, 0, time.UTC), Mode: GameModeDaily, Number: 929, Luck: luck(60)},
+		{Date: time.Date(2024, time.January, 5, 0, 0, 0, 0, time.UTC), Mode: GameModeDaily, Number: 930, HardMode: true},
+		{Date: time.Date(2024, time.January, 6, 0, 0, 0, 0, time.UTC), Mode: GameModeGroup, Group: "work"},
+		{Date: time.Date(2024, time.January, 7, 0, 0, 0, 0, time.UTC), Mode: GameModeRandom, Difficulty: luck(3)},
+		{Date: time.Date(2024, time.January, 8, 0, 0, 0, 0, time.UTC), Mode: GameModeRandom, Flags: []string{"assisted"}},
+		{Date: time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC), Mode: GameModeDaily, Number: 957},
+		{Date: cutoff, Mode: GameModeDaily, Number: 986},
+		{Date: cutoff.AddDate(0, 0, 1), Mode: GameModeRandom},
+	}}
+
+	compacted := gs.compactHistory(cutoff)
+	if compacted != 7 {
+		t.Errorf("compacted %d games, want 7", compacted)
+	}
+
+	if len(gs.History) != 2 || gs.History[0].Number != 986 {
+		t.Errorf("kept %+v, want the two games from the cutoff on", gs.History)
+	}
+
+	want := []HistorySummary{
+		{Month: "2024-01", Mode: GameModeDaily, Numbers: []int{928, 929}, Luck: 100, LuckGames: 2},
+		{Month: "2024-01", Mode: GameModeDaily, HardMode: true, Numbers: []int{930}},
+		{Month: "2024-01", Mode: GameModeGroup, Group: "work"},
+		{Month: "2024-01", Mode: GameModeRandom, Difficulty: 3, DifficultyGames: 1},
+		{Month: "2024-01", Mode: GameModeRandom, Flags: []string{"assisted"}},
+		{Month: "2024-02", Mode: GameModeDaily, Numbers: []int{957}},
+	}
+
+	if !reflect.DeepEqual(gs.Compacted, want) {
+		t.Errorf("summaries\n%+v\nwant\n%+v", gs.Compacted, want)
+	}
+
+	// compacting again folds into the summaries already there
+	gs.History = append(gs.History, GameRecord{Date: time.Date(2024, time.January, 9, 0, 0, 0, 0, time.UTC), Mode: GameModeDaily, Number: 934})
+
+	if compacted := gs.compactHistory(cutoff); compacted != 1 || len(gs.Compacted) != len(want) {
+		t.Fatalf("compacted %d games into %d summaries, want 1 into %d", compacted, len(gs.Compacted), len(want))
+	}
+
+	if numbers := gs.Compacted[0].Numbers; !reflect.DeepEqual(numbers, []int{928, 929, 934}) {
+		t.Errorf("numbers %v, want [928 929 934]", numbers)
+	}
+}
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+var shortOrdinals = []string{"1st", "2nd", "3rd", "4th", "5th"}
+
+// Constraints is everything the revealed hints say about the answer.
+type Constraints struct {
+	// Located holds the letter known to be at each position, 0 when unknown.
+	Located []byte
+	// NotAt holds the letters known not to be at each position.
+	NotAt []map[byte]bool
+	// MinCount holds how many times each letter is known to be in the answer.
+	MinCount map[byte]int
+	// Exact is set for letters whose count in the answer is fully known.
+	Exact map[byte]bool
+}
+
+// buildConstraints works out what the scored guesses reveal about the answer.
+func buildConstraints(guesses []string, answer string) *Constraints {
+	c := &Constraints{
+		Located:  make([]byte, WordLength),
+		NotAt:    make([]map[byte]bool, WordLength),
+		MinCount: map[byte]int{},
+		Exact:    map[byte]bool{},
+	}
+
+	for i := range c.NotAt {
+		c.NotAt[i] = map[byte]bool{}
+	}
+
+	for _, guess := range guesses {
+		hints := scoreGuess(guess, answer)
+		found := map[byte]int{}
+
+		for i, hint := range hints {
+			letter := guess[i]
+
+			switch hint {
+			case KeyHintLocated:
+				c.Located[i] = letter
+				found[letter]++
+			case KeyHintSomewhere:
+				c.NotAt[i][letter] = true
+				found[letter]++
+			default:
+				// a red letter means every copy of it has been found
+				c.NotAt[i][letter] = true
+				c.Exact[letter] = true
+			}
+		}
+
+		for letter, count := range found {
+			if count > c.MinCount[letter] {
+				c.MinCount[letter] = count
+			}
+		}
+	}
+
+	return c
+}
+
+// LocatedCount is how many copies of a letter are known to be in place.
+func (c *Constraints) LocatedCount(letter byte) int {
+	count := 0
+
+	for _, located := range c.Located {
+		if located == letter {
+			count++
+		}
+	}
+
+	return count
+}
+
+// hardModeViolation checks the guess against everything revealed so far and
+// explains the first hard mode rule it breaks, or returns an empty string.
+func hardModeViolation(guess string) *Rejection {
+	return buildConstraints(guessHistory, word).HardModeViolation(guess)
+}
+
+// HardModeViolation explains the first hard mode rule the guess breaks, or
+// returns nil. Green letters have to stay where they are and yellow letters
+// have to be used again.
+func (c *Constraints) HardModeViolation(guess string) *Rejection {
+	for i, letter := range c.Located {
+		if letter != 0 && guess[i] != letter {
+			return &Rejection{RejectHardModeGreen, fmt.Sprintf("%s letter must be %c", shortOrdinals[i], letter)}
+		}
+	}
+
+	used := mapString(guess)
+
+	for _, letter := range c.Present() {
+		if used[letter] >= c.MinCount[letter] {
+			continue
+		}
+
+		if c.MinCount[letter] == 1 {
+			return &Rejection{RejectHardModeYellow, fmt.Sprintf("guess must contain %c", letter)}
+		}
+
+		return &Rejection{RejectHardModeYellow, fmt.Sprintf("guess must contain %s", countLetter(c.MinCount[letter], letter))}
+	}
+
+	return nil
+}
+
+// wastedLetters lists the letters in guess that earlier guesses have already
+// ruled out, either because the answer doesn't have them or because the guess
+// uses more copies than the answer is known to have, e.g. "S" or "two E's".
+func wastedLetters(guess string) []string {
+	known := buildConstraints(guessHistory, word)
+	used := mapString(guess)
+	wasted := []string{}
+
+	for i := range guess {
+		letter := guess[i]
+		if used[letter] == 0 || !known.Exact[letter] || used[letter] <= known.MinCount[letter] {
+			continue
+		}
+
+		if known.MinCount[letter] == 0 {
+			wasted = append(wasted, string(letter))
+		} else {
+			wasted = append(wasted, countLetter(used[letter], letter))
+		}
+
+		used[letter] = 0 // only report each letter once
+	}
+
+	return wasted
+}
+
+// NoRepeatGuesses is how many of the first guesses --no-repeats keeps from
+// repeating a letter.
+const NoRepeatGuesses = 2
+
+// noRepeatViolation explains why the guess breaks the --no-repeats rule, or
+// returns nil. Until NoRepeatGuesses have been made, a guess can't
+// use a letter twice or use one an earlier guess already tried.
+func noRepeatViolation(guess string) *Rejection {
+	if len(guessHistory) >= NoRepeatGuesses {
+		return nil
+	}
+
+	used := mapString(guess)
+
+	for i := range guess {
+		if used[guess[i]] > 1 {
+			return &Rejection{RejectRepeatedLetter, fmt.Sprintf("no repeats: %c is used twice", guess[i])}
+		}
+	}
+
+	for _, earlier := range guessHistory {
+		for i := range guess {
+			if strings.IndexByte(earlier, guess[i]) >= 0 {
+				return &Rejection{RejectRepeatedLetter, fmt.Sprintf("no repeats: %c was already tried", guess[i])}
+			}
+		}
+	}
+
+	return nil
+}
+
+// Present lists the letters known to be in the answer.
+func (c *Constraints) Present() []byte {
+	letters := []byte{}
+
+	for letter, count := range c.MinCount {
+		if count > 0 {
+			letters = append(letters, letter)
+		}
+	}
+
+	sort.Slice(letters, func(i, j int) bool { return letters[i] < letters[j] })
+
+	return letters
+}
+
+// Excluded lists the letters known not to be in the answer at all.
+func (c *Constraints) Excluded() []byte {
+	letters := []byte{}
+
+	for letter := range c.Exact {
+		if c.MinCount[letter] == 0 {
+			letters = append(letters, letter)
+		}
+	}
+
+	sort.Slice(letters, func(i, j int) bool { return letters[i] < letters[j] })
+
+	return letters
+}
+
+// Summary describes the constraints in one line, e.g.
+// "Known: _ R A _ _  In word: E T  Not in word: C N S".
+func (c *Constraints) Summary() string {
+	slots := make([]string, WordLength)
+
+	for i, letter := range c.Located {
+		slots[i] = "_"
+		if letter != 0 {
+			slots[i] = string(letter)
+		}
+	}
+
+	return "Known: " + strings.Join(slots, " ") +
+		"  In word: " + spellLetters(c.Present()) +
+		"  Not in word: " + spellLetters(c.Excluded())
+}
+
+// spellLetters joins letters with spaces, or a dash when there are none.
+func spellLetters(letters []byte) string {
+	if len(letters) == 0 {
+		return "-"
+	}
+
+	return strings.Join(strings.Split(string(letters), ""), " ")
+}
@@ -0,0 +1,42 @@
+package main
+
+import "testing"
+
+func TestHardModeViolation(t *testing.T) {
+	tests := []struct {
+		name    string
+		answer  string
+		guesses []string
+		guess   string
+		code    string
+		reason  string
+	}{
+		{"nothing guessed yet", "SHAKE", nil, "BUMPY", "", ""},
+		{"keeps the greens", "SHAKE", []string{"CRANE"}, "STALE", "", ""},
+		{"moves a green", "SHAKE", []string{"CRANE"}, "SLATS", RejectHardModeGreen, "5th letter must be E"},
+		{"drops a green", "SHAKE", []string{"CRANE"}, "BLIMP", RejectHardModeGreen, "3rd letter must be A"},
+		{"drops a yellow", "SHAKE", []string{"SPEAK"}, "SHAME", RejectHardModeYellow, "guess must contain K"},
+		{"uses every yellow", "SHAKE", []string{"SPEAK"}, "SKATE", "", ""},
+		{"yellow anywhere, even where it was", "SHAKE", []string{"SPEAK"}, "SEKAS", "", ""},
+		{"short on copies", "LOYAL", []string{"ALLOT"}, "ATOLL", "", ""},
+		{"needs both copies", "LOYAL", []string{"ALLOT"}, "ALOFT", RejectHardModeYellow, "guess must contain two L's"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := buildConstraints(tt.guesses, tt.answer).HardModeViolation(tt.guess)
+
+			if tt.code == "" {
+				if got != nil {
+					t.Errorf("HardModeViolation(%q) = %+v, want nil", tt.guess, got)
+				}
+
+				return
+			}
+
+			if got == nil || got.Code != tt.code || got.Reason != tt.reason {
+				t.Errorf("HardModeViolation(%q) = %+v, want %s %q", tt.guess, got, tt.code, tt.reason)
+			}
+		})
+	}
+}
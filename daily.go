@@ -0,0 +1,165 @@
+package main
+
+import (
+	"sort"
+	"time"
+)
+
+// What happens to a game interrupted after the first guess.
+const (
+	// InterruptResume saves dailies to be finished later, other games are lost.
+	InterruptResume = "resume"
+	// InterruptForfeit counts every interrupted game as lost.
+	InterruptForfeit = "forfeit"
+	// InterruptGrace saves dailies and forgets other games entirely.
+	InterruptGrace = "grace"
+)
+
+// interruptPolicy is the interrupt_policy config, InterruptResume when it
+// isn't set. An unknown policy is taken as InterruptResume too, but isn't ok
+// so it can be warned about.
+func (gs *GameStats) interruptPolicy() (policy string, ok bool) {
+	switch gs.InterruptPolicy {
+	case InterruptResume, InterruptForfeit, InterruptGrace:
+		return gs.InterruptPolicy, true
+	case "":
+		return InterruptResume, true
+	default:
+		return InterruptResume, false
+	}
+}
+
+// DailyProgress is a daily puzzle that was started but not finished, kept so
+// it can be picked back up later in the same mode it was started in.
+type DailyProgress struct {
+	Day      int       `json:"day"`
+	Group    string    `json:"group,omitempty"`
+	Answer   string    `json:"answer"`
+	HardMode bool      `json:"hard_mode"`
+	Started  time.Time `json:"started"`
+	Guesses  []string  `json:"guesses"`
+	Assisted bool      `json:"assisted,omitempty"`
+}
+
+// PendingDailies are the unfinished dailies by group, "" for the official
+// daily, so starting one doesn't disturb another left unfinished.
+type PendingDailies map[string]*DailyProgress
+
+// resumableDaily returns today's unfinished daily for the current group, if
+// there is one. Unfinished dailies left over from an earlier day have been
+// abandoned and are counted as losses.
+func (gs *GameStats) resumableDaily() *DailyProgress {
+	groups := make([]string, 0, len(gs.PendingDailies))
+	for group := range gs.PendingDailies {
+		groups = append(groups, group)
+	}
+
+	// in order so the history comes out the same every time
+	sort.Strings(groups)
+
+	for _, group := range groups {
+		if progress := gs.PendingDailies[group]; progress.Day != dayOffset {
+			gs.abandonDaily(progress)
+			delete(gs.PendingDailies, group)
+		}
+	}
+
+	return gs.PendingDailies[args.Group]
+}
+
+// abandonDaily counts an unfinished daily from an earlier day as lost.
+func (gs *GameStats) abandonDaily(progress *DailyProgress) {
+	mode := GameModeDaily
+	number := progress.Day + gs.PuzzleNumberOffset
+
+	if progress.Group != "" {
+		mode = GameModeGroup
+		number = 0
+	}
+
+	if progress.Assisted {
+		gs.countAssisted(progress.HardMode, false, 0)
+	} else {
+		gs.Streak = 0
+	}
+
+	gs.History = append(gs.History, GameRecord{
+		Date:     progress.Started,
+		Answer:   progress.Answer,
+		Guesses:  progress.Guesses,
+		Won:      false,
+		HardMode: progress.HardMode,
+		Group:    progress.Group,
+		Number:   number,
+		Mode:     mode,
+		Flags:    assistedFlags(progress.Assisted),
+	})
+}
+
+// trackDaily remembers the guesses made so far in today's daily.
+func (gs *GameStats) trackDaily() {
+	guesses := make([]string, len(guessHistory))
+	copy(guesses, guessHistory)
+
+	if gs.PendingDailies == nil {
+		gs.PendingDailies = PendingDailies{}
+	}
+
+	progress := gs.PendingDailies[args.Group]
+	if progress == nil {
+		progress = &DailyProgress{
+			Day:      dayOffset,
+			Group:    args.Group,
+			Answer:   word,
+			HardMode: args.HardMode,
+			Started:  time.Now(),
+		}
+		gs.PendingDailies[args.Group] = progress
+	}
+
+	progress.Guesses = guesses
+	progress.Assisted = assisted
+}
+
+// finishDaily forgets the current group's unfinished daily once it's over.
+func (gs *GameStats) finishDaily() {
+	delete(gs.PendingDailies, args.Group)
+}
+
+// migrateDailies works out which day's daily was played last for stats saved
+// before the day was recorded. Back then the date was kept as midnight in the
+// local time zone but with the date the daily had in UTC, so the date it
+// shows is the daily's, whatever time zone it was written in.
+func (gs *GameStats) migrateDailies() {
+	if progress := gs.DailyInProgress; progress != nil {
+		if gs.PendingDailies == nil {
+			gs.PendingDailies = PendingDailies{}
+		}
+
+		gs.PendingDailies[progress.Group] = progress
+		gs.DailyInProgress = nil
+	}
+
+	if gs.LastDaily != nil && gs.LastDailyDay == 0 {
+		gs.LastDailyDay = dailyDay(*gs.LastDaily)
+	}
+
+	for group, last := range gs.GroupDailies {
+		if _, ok := gs.GroupDailyDays[group]; ok || last == nil {
+			continue
+		}
+
+		if gs.GroupDailyDays == nil {
+			gs.GroupDailyDays = map[string]int{}
+		}
+
+		gs.GroupDailyDays[group] = dailyDay(*last)
+	}
+}
+
+// dailyDay is the day of the daily on the date t shows.
+func dailyDay(t time.Time) int {
+	date := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+
+	return int(date.Sub(dailyEpoch).Hours() / 24)
+}
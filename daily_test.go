@@ -0,0 +1,98 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMigrateDailies(t *testing.T) {
+	// saved as local midnight with the UTC date of the daily, so the wall
+	// clock date is what counts wherever it was written
+	east := time.FixedZone("UTC+10", 10*60*60)
+	west := time.FixedZone("UTC-8", -8*60*60)
+	day1000 := time.Date(2024, time.March, 15, 0, 0, 0, 0, east)
+	day999 := time.Date(2024, time.March, 14, 0, 0, 0, 0, west)
+	progress := &DailyProgress{Day: 1001, Answer: "SHAKE"}
+
+	tests := []struct {
+		name       string
+		stats      GameStats
+		lastDay    int
+		groupDays  map[string]int
+		pending    PendingDailies
+		inProgress *DailyProgress
+	}{
+		{
+			name:  "nothing to do",
+			stats: GameStats{},
+		},
+		{
+			name:    "last daily",
+			stats:   GameStats{LastDaily: &day1000},
+			lastDay: 1000,
+		},
+		{
+			name:    "day already kept",
+			stats:   GameStats{LastDaily: &day1000, LastDailyDay: 1002},
+			lastDay: 1002,
+		},
+		{
+			name:      "groups",
+			stats:     GameStats{GroupDailies: map[string]*time.Time{"work": &day999, "home": &day1000, "gone": nil}},
+			groupDays: map[string]int{"work": 999, "home": 1000},
+		},
+		{
+			name: "group day already kept",
+			stats: GameStats{
+				GroupDailies:   map[string]*time.Time{"work": &day999, "home": &day1000},
+				GroupDailyDays: map[string]int{"work": 1002},
+			},
+			groupDays: map[string]int{"work": 1002, "home": 1000},
+		},
+		{
+			name:    "unfinished daily",
+			stats:   GameStats{DailyInProgress: progress},
+			pending: PendingDailies{"": progress},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gs := tt.stats
+			gs.migrateDailies()
+
+			if gs.LastDailyDay != tt.lastDay {
+				t.Errorf("LastDailyDay = %d, want %d", gs.LastDailyDay, tt.lastDay)
+			}
+
+			if !reflect.DeepEqual(gs.GroupDailyDays, tt.groupDays) {
+				t.Errorf("GroupDailyDays = %v, want %v", gs.GroupDailyDays, tt.groupDays)
+			}
+
+			if !reflect.DeepEqual(gs.PendingDailies, tt.pending) || gs.DailyInProgress != tt.inProgress {
+				t.Errorf("PendingDailies = %v and DailyInProgress = %v, want %v and %v",
+					gs.PendingDailies, gs.DailyInProgress, tt.pending, tt.inProgress)
+			}
+		})
+	}
+}
+
+func TestDailyDay(t *testing.T) {
+	tests := []struct {
+		t    time.Time
+		want int
+	}{
+		{dailyEpoch, 0},
+		{dailyEpoch.Add(23 * time.Hour), 0},
+		{dailyEpoch.AddDate(0, 0, 1000), 1000},
+		{time.Date(2024, time.March, 15, 0, 0, 0, 0, time.FixedZone("UTC+10", 10*60*60)), 1000},
+		{time.Date(2024, time.March, 15, 23, 59, 0, 0, time.FixedZone("UTC-8", -8*60*60)), 1000},
+	}
+
+	for _, tt := range tests {
+		if got := dailyDay(tt.t); got != tt.want {
+			t.Errorf("dailyDay(%v) = %d, want %d", tt.t, got, tt.want)
+		}
+	}
+}
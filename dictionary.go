@@ -0,0 +1,111 @@
+package main
+
+import (
+	_ "embed"
+	"encoding/binary"
+	"sort"
+)
+
+//go:generate go run gen_answer_order.go
+
+//go:embed answer_order.bin
+var rawAnswerOrder []byte
+
+// sortedAnswers is the answers in alphabetical order, set by parseWordLists.
+var sortedAnswers []string
+
+// Dictionary is a sorted list of words packed end to end, WordLength bytes
+// apiece, so checking a guess is a binary search that doesn't allocate.
+type Dictionary []byte
+
+// dictionary is every valid guess, answers included.
+var dictionary Dictionary
+
+// Len is the number of words in the dictionary.
+func (d Dictionary) Len() int {
+	return len(d) / WordLength
+}
+
+// Word is the i'th word in sorted order.
+func (d Dictionary) Word(i int) string {
+	return string(d[i*WordLength : (i+1)*WordLength])
+}
+
+// Contains checks if w is in the dictionary.
+func (d Dictionary) Contains(w string) bool {
+	if len(w) != WordLength {
+		return false
+	}
+
+	i := sort.Search(d.Len(), func(i int) bool {
+		return string(d[i*WordLength:(i+1)*WordLength]) >= w
+	})
+
+	return i < d.Len() && string(d[i*WordLength:(i+1)*WordLength]) == w
+}
+
+// sortAnswers puts the answers, which are kept in daily order, in alphabetical
+// order using the index go generate writes to answer_order.bin. An index that
+// doesn't sort them, because the list was edited since, is caught in one pass
+// and they're sorted the slow way.
+func sortAnswers(answers []string) []string {
+	sorted := make([]string, 0, len(answers))
+
+	if len(rawAnswerOrder) == 2*len(answers) {
+		for i := range answers {
+			j := int(binary.BigEndian.Uint16(rawAnswerOrder[2*i:]))
+			if j >= len(answers) {
+				break
+			}
+
+			sorted = append(sorted, answers[j])
+		}
+
+		// strictly increasing means each answer is there exactly once
+		if len(sorted) == len(answers) && strictlySorted(sorted) {
+			return sorted
+		}
+	}
+
+	sorted = append(sorted[:0], answers...)
+	sort.Strings(sorted)
+
+	return sorted
+}
+
+func strictlySorted(words []string) bool {
+	for i := 1; i < len(words); i++ {
+		if words[i-1] >= words[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// buildDictionary merges the answers with the rest of the allowed words, both
+// of which have to be sorted already.
+func buildDictionary(sorted []string, allowed []string) Dictionary {
+	rest := allowed
+	d := make(Dictionary, 0, (len(sorted)+len(rest))*WordLength)
+
+	for len(sorted) > 0 || len(rest) > 0 {
+		var next string
+
+		switch {
+		case len(rest) == 0 || (len(sorted) > 0 && sorted[0] <= rest[0]):
+			next, sorted = sorted[0], sorted[1:]
+		default:
+			next, rest = rest[0], rest[1:]
+		}
+
+		// skip duplicates and anything that isn't a word
+		if len(next) != WordLength || (d.Len() > 0 && d.Word(d.Len()-1) == next) {
+			continue
+		}
+
+		d = append(d, next...)
+	}
+
+	return d
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"sort"
+)
+
+const (
+	AdaptiveWindow = 10
+
+	TierEasy   = 0
+	TierMedium = 1
+	TierHard   = 2
+	TierCount  = 3
+)
+
+var tierNames = []string{"common letters", "mixed letters", "rare letters"}
+
+// AdaptiveStats tallies adaptive games apart from the regular stats since
+// their word pool and guess budget aren't the same as a normal game's.
+type AdaptiveStats struct {
+	Games int   `json:"games"`
+	Wins  []int `json:"wins"`
+}
+
+// countAdaptive moves a finished adaptive game out of the regular stats, where
+// it was counted on its first guess, and into the adaptive bucket.
+func (gs *GameStats) countAdaptive(hardMode bool, win bool, guesses int) {
+	if hardMode {
+		gs.TotalHardGames--
+	} else {
+		gs.TotalGames--
+	}
+
+	if len(gs.Adaptive.Wins) != TotalGuesses {
+		gs.Adaptive.Wins = make([]int, TotalGuesses)
+	}
+
+	gs.Adaptive.Games++
+
+	if win && guesses > 0 && guesses <= TotalGuesses {
+		gs.Adaptive.Wins[guesses-1]++
+	}
+}
+
+// letterCommonness is the share of words in the list each letter appears in.
+func letterCommonness(words []string) map[rune]float64 {
+	counts := map[rune]int{}
+
+	for _, w := range words {
+		seen := map[rune]bool{}
+
+		for _, r := range w {
+			if !seen[r] {
+				seen[r] = true
+				counts[r]++
+			}
+		}
+	}
+
+	commonness := map[rune]float64{}
+	for r, count := range counts {
+		commonness[r] = float64(count) / float64(len(words))
+	}
+
+	return commonness
+}
+
+// wordDifficulty scores how hard a word is likely to be: rare letters and
+// repeated letters both make a word harder to find.
+func wordDifficulty(w string, commonness map[rune]float64) float64 {
+	difficulty := float64(0)
+	seen := map[rune]bool{}
+
+	for _, r := range w {
+		difficulty += 1 - commonness[r]
+
+		if seen[r] {
+			difficulty += 1
+		}
+
+		seen[r] = true
+	}
+
+	return difficulty / float64(len(w))
+}
+
+// difficultyTiers splits the words into easy, medium, and hard thirds. Tiers
+// by how often words are used would be better, but without a word frequency
+// list to go on they're ranked by wordDifficulty instead.
+func difficultyTiers(words []string) [][]string {
+	commonness := letterCommonness(words)
+
+	sorted := make([]string, len(words))
+	copy(sorted, words)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return wordDifficulty(sorted[i], commonness) < wordDifficulty(sorted[j], commonness)
+	})
+
+	tiers := make([][]string, TierCount)
+	for i := range tiers {
+		tiers[i] = sorted[i*len(sorted)/TierCount : (i+1)*len(sorted)/TierCount]
+	}
+
+	return tiers
+}
+
+// adaptiveSettings picks a difficulty tier and a guess budget from the win
+// rate of the most recent games so the challenge stays about the same.
+func (gs *GameStats) adaptiveSettings() (tier int, budget int) {
+	games := 0
+	wins := 0
+
+	for i := len(gs.History) - 1; i >= 0 && games < AdaptiveWindow; i-- {
+		if gs.History[i].Imported {
+			continue
+		}
+
+		games++
+
+		if gs.History[i].Won {
+			wins++
+		}
+	}
+
+	if games == 0 {
+		return TierMedium, TotalGuesses
+	}
+
+	rate := float64(wins) / float64(games)
+
+	switch {
+	case rate < 0.6:
+		return TierEasy, TotalGuesses
+	case rate < 0.85:
+		return TierMedium, TotalGuesses
+	default:
+		return TierHard, TotalGuesses - 1
+	}
+}
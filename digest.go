@@ -0,0 +1,149 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	DigestBestSolves = 3
+
+	// SMTPUserEnv and SMTPPasswordEnv hold the login for --smtp, so it doesn't
+	// end up in a crontab or the process list.
+	SMTPUserEnv     = "WORDLE_SMTP_USER"
+	SMTPPasswordEnv = "WORDLE_SMTP_PASSWORD"
+)
+
+type DigestCommand struct {
+	Days int    `long:"days" default:"7" description:"How many days back the digest covers" value-name:"N"`
+	SMTP string `long:"smtp" description:"Email the digest through this SMTP server instead of printing it" value-name:"HOST:PORT"`
+	From string `long:"from" description:"Address the email is from" value-name:"ADDRESS"`
+	To   string `long:"to" description:"Address the email is sent to" value-name:"ADDRESS"`
+}
+
+func (cmd *DigestCommand) Execute(_ []string) error {
+	gamestats := loadGameStats()
+
+	since := time.Now().AddDate(0, 0, -cmd.Days)
+	digest := gamestats.digest(since)
+
+	if cmd.SMTP == "" {
+		fmt.Print(digest)
+		return nil
+	}
+
+	if cmd.From == "" || cmd.To == "" {
+		return errors.New("--smtp needs --from and --to")
+	}
+
+	return sendDigest(cmd.SMTP, cmd.From, cmd.To, digest)
+}
+
+// digest summarizes the games played since a given time.
+func (gs *GameStats) digest(since time.Time) string {
+	var games []GameRecord
+
+	for _, game := range gs.History {
+		if game.Date.After(since) {
+			games = append(games, game)
+		}
+	}
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Wordle Digest, %s to %s\n\n", since.Format("Jan 2"), time.Now().Format("Jan 2"))
+
+	wins := 0
+	solves := []GameRecord{}
+	dailies := 0
+	guesses := 0
+
+	for _, game := range games {
+		if game.Mode == GameModeDaily {
+			dailies++
+		}
+
+		if !game.Won {
+			continue
+		}
+
+		wins++
+
+		// imported games only kept their colors, not their guesses
+		if len(game.Guesses) > 0 {
+			solves = append(solves, game)
+			guesses += len(game.Guesses)
+		}
+	}
+
+	fmt.Fprintf(&b, "       Games: %d\n", len(games))
+
+	if len(games) > 0 {
+		fmt.Fprintf(&b, "        Wins: %d (%d%%)\n", wins, wins*100/len(games))
+	}
+
+	if len(solves) > 0 {
+		fmt.Fprintf(&b, "Avg. Guesses: %.1f\n", float64(guesses)/float64(len(solves)))
+	}
+
+	fmt.Fprintf(&b, "     Dailies: %d\n", dailies)
+	fmt.Fprintf(&b, "      Streak: %d (best %d)\n", gs.Streak, gs.BestStreak)
+
+	if gs.LastDailyDay < int(time.Since(dailyEpoch).Hours()/24) {
+		fmt.Fprint(&b, "\nToday's daily hasn't been played yet.\n")
+	}
+
+	if len(solves) == 0 {
+		return b.String()
+	}
+
+	sort.SliceStable(solves, func(i, j int) bool {
+		return len(solves[i].Guesses) < len(solves[j].Guesses)
+	})
+
+	if len(solves) > DigestBestSolves {
+		solves = solves[:DigestBestSolves]
+	}
+
+	fmt.Fprint(&b, "\nBest Solves:\n\n")
+
+	for _, game := range solves {
+		fmt.Fprintf(&b, "%s  %s in %d\n", game.Date.Format("Mon Jan 2"), game.Answer, len(game.Guesses))
+	}
+
+	return b.String()
+}
+
+// sendDigest emails a digest. The server is logged in to when a login is set
+// in the environment, net/smtp only sends it once the connection is encrypted
+// or to localhost.
+func sendDigest(server string, from string, to string, digest string) error {
+	host, _, err := net.SplitHostPort(server)
+	if err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if user := os.Getenv(SMTPUserEnv); user != "" {
+		auth = smtp.PlainAuth("", user, os.Getenv(SMTPPasswordEnv), host)
+	}
+
+	headers := []string{
+		"From: " + from,
+		"To: " + to,
+		"Subject: Wordle Digest",
+		"Date: " + time.Now().Format(time.RFC1123Z),
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=utf-8",
+	}
+
+	msg := strings.Join(headers, "\r\n") + "\r\n\r\n" + strings.ReplaceAll(digest, "\n", "\r\n")
+
+	return smtp.SendMail(server, auth, from, []string{to}, []byte(msg))
+}
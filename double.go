@@ -0,0 +1,312 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-tty"
+)
+
+const (
+	DoubleAnswers = 2
+	DoubleGuesses = 8
+)
+
+type DoubleStats struct {
+	Games int   `json:"games"`
+	Wins  []int `json:"wins"`
+}
+
+type DoubleCommand struct {
+	Stats bool `short:"s" long:"stats" description:"Print double stats without playing"`
+}
+
+func (cmd *DoubleCommand) Execute(_ []string) error {
+	gamestats := loadGameStats()
+	gamestats.applyTheme()
+
+	if cmd.Stats {
+		gamestats.Double.print()
+		return nil
+	}
+
+	parseWordLists()
+
+	rand.Seed(time.Now().UnixNano())
+
+	answers := make([]string, DoubleAnswers)
+	for i, j := range rand.Perm(len(wordList))[:DoubleAnswers] {
+		answers[i] = wordList[j]
+	}
+
+	resetGame()
+	boardRows = DoubleGuesses
+
+	fmt.Println("    Double Trouble!")
+
+	ty, err := tty.Open()
+	if err != nil {
+		return err
+	}
+
+	tyOpen := true
+	defer func() {
+		if tyOpen {
+			ty.Close()
+		}
+	}()
+
+	anchorBoard()
+
+	stat, err := newScreen(DoubleGuesses + 1 + KeyboardRows + PanelLines) // +1 for "status" line
+	if err != nil {
+		return err
+	}
+	defer stat.Finish()
+
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, os.Interrupt)
+
+	go func() {
+		<-c
+
+		ty.Close()
+		stat.Finish()
+		fmt.Printf("\nThe words were %s\n", strings.Join(answers, " and "))
+		os.Exit(0)
+	}()
+
+	for i := 0; i < DoubleGuesses; i++ {
+		_, _ = stat.WriteString(guessLine(i), "     _ _ _ _ _")
+	}
+
+	printKeyboard(stat)
+
+	solved := make([]bool, DoubleAnswers)
+	share := []string{}
+
+	// the other word's column is left blank, emoji take two columns each
+	blank := strings.Repeat(" ", WordLength*2)
+	if gamestats.shareFormat() == ShareFormatText {
+		blank = strings.Repeat(" ", WordLength)
+	}
+
+	for currentGuess < DoubleGuesses && countTrue(solved) < DoubleAnswers {
+		_, _ = stat.WriteString(statusLine(), fmt.Sprintf("Solved %d of %d", countTrue(solved), DoubleAnswers))
+
+		guess, err := readGuess(ty, stat, guessLine(currentGuess), mustBeWord)
+		if err != nil {
+			return err
+		}
+
+		target, hints := bestMatch(guess, answers, solved)
+		if guess == answers[target] {
+			solved[target] = true
+		}
+
+		_, _ = stat.WriteString(guessLine(currentGuess), colorGuess(guess, hints)+fmt.Sprintf("  (word %d)", target+1))
+
+		updateDoubleKeyboard(guess, hints, answers, solved)
+		printKeyboard(stat)
+
+		row := make([]string, DoubleAnswers)
+		for i := range answers {
+			row[i] = blank
+			if i == target {
+				row[i] = hintEmoji(hints)
+			}
+		}
+
+		share = append(share, strings.Join(row, " "))
+		currentGuess++
+
+		if currentGuess < DoubleGuesses && countTrue(solved) < DoubleAnswers {
+			advanceGuessRow(stat)
+		}
+	}
+
+	stat.Finish()
+	ty.Close()
+	tyOpen = false
+
+	win := countTrue(solved) == DoubleAnswers
+	gamestats.Double.record(win, currentGuess)
+	_ = gamestats.save()
+
+	if win {
+		fmt.Print("You win!\n\n")
+	} else {
+		fmt.Printf("\nThe words were %s\n\n", strings.Join(answers, " and "))
+	}
+
+	gamestats.Double.print()
+
+	if !gamestats.useEmoji() {
+		return nil
+	}
+
+	turn := "X"
+	if win {
+		turn = fmt.Sprint(currentGuess)
+	}
+
+	fmt.Println()
+	fmt.Printf("Wordle Double %s/%d\n\n", turn, DoubleGuesses)
+
+	for _, line := range share {
+		fmt.Println(gamestats.shareLine(strings.TrimRight(line, " ")))
+	}
+
+	return nil
+}
+
+// bestMatch scores the guess against every answer that's still unsolved and
+// picks the one it matches best, greens counting double.
+func bestMatch(guess string, answers []string, solved []bool) (target int, hints []KeyHint) {
+	best := -1
+
+	for i, answer := range answers {
+		if solved[i] {
+			continue
+		}
+
+		scored := scoreGuess(guess, answer)
+
+		points := 0
+		for _, hint := range scored {
+			switch hint {
+			case KeyHintLocated:
+				points += 2
+			case KeyHintSomewhere:
+				points++
+			}
+		}
+
+		if guess == answer {
+			points = WordLength * 3
+		}
+
+		if points > best {
+			best = points
+			target = i
+			hints = scored
+		}
+	}
+
+	return target, hints
+}
+
+// updateDoubleKeyboard marks the keyboard with what a guess revealed. A
+// letter is only ruled out once none of the unsolved answers have it.
+func updateDoubleKeyboard(guess string, hints []KeyHint, answers []string, solved []bool) {
+	for i, hint := range hints {
+		letter := rune(guess[i])
+
+		if hint != KeyHintNotInWord {
+			setKeyHint(letter, hint)
+			continue
+		}
+
+		found := false
+
+		for j, answer := range answers {
+			if !solved[j] && strings.ContainsRune(answer, letter) {
+				found = true
+			}
+		}
+
+		if !found {
+			setKeyHint(letter, KeyHintNotInWord)
+		}
+	}
+}
+
+// colorGuess draws a scored guess row.
+func colorGuess(guess string, hints []KeyHint) string {
+	slots := make([]string, len(guess))
+
+	for i := range guess {
+		sprintf := tileColorFns[hints[i]]
+		if hints[i] == KeyHintNotInWord || hints[i] == KeyHintUnknown {
+			sprintf = tileColorFns[KeyHintNotInWord]
+		}
+
+		slots[i] = sprintf(string(guess[i]))
+	}
+
+	return "     " + strings.Join(slots, " ")
+}
+
+// hintEmoji turns scored letters into a row of share emoji.
+func hintEmoji(hints []KeyHint) string {
+	emoji := make([]rune, len(hints))
+
+	for i, hint := range hints {
+		switch hint {
+		case KeyHintLocated:
+			emoji[i] = EmojiLocated
+		case KeyHintSomewhere:
+			emoji[i] = EmojiSomewhere
+		default:
+			emoji[i] = EmojiNotInWord
+		}
+	}
+
+	return string(emoji)
+}
+
+func countTrue(values []bool) int {
+	count := 0
+
+	for _, value := range values {
+		if value {
+			count++
+		}
+	}
+
+	return count
+}
+
+func (ds *DoubleStats) record(win bool, guesses int) {
+	if len(ds.Wins) != DoubleGuesses {
+		ds.Wins = make([]int, DoubleGuesses)
+	}
+
+	ds.Games++
+
+	if win {
+		ds.Wins[guesses-1]++
+	}
+}
+
+func (ds *DoubleStats) print() {
+	fmt.Print("Double Stats\n\n")
+	fmt.Printf("Total Games: %d\n", ds.Games)
+
+	if ds.Games == 0 {
+		return
+	}
+
+	max := 0
+	wins := 0
+
+	for _, count := range ds.Wins {
+		wins += count
+
+		if count > max {
+			max = count
+		}
+	}
+
+	fmt.Printf("      Win %%: %d\n", wins*100/ds.Games)
+	fmt.Println()
+	fmt.Print("Guess Distribution:\n\n")
+
+	for i, count := range ds.Wins {
+		fmt.Printf("%d: %d %s\n", i+1, count, histogramBar(count, max))
+	}
+}
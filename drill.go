@@ -0,0 +1,237 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-tty"
+)
+
+const (
+	// DrillBlank marks the letters of a drill pattern that can be anything.
+	DrillBlank = '_'
+	// DrillMinFamily is the fewest answers a pattern needs to be worth a
+	// drill, fewer and the last words are given away.
+	DrillMinFamily = 4
+)
+
+type DrillCommand struct {
+	Pattern string `short:"p" long:"pattern" description:"Letters the answers share, with _ for the rest, e.g. _IGHT. A random family when not set" value-name:"PATTERN"`
+	Rounds  int    `long:"rounds" default:"5" description:"How many words of the family to play" value-name:"N"`
+}
+
+func (cmd *DrillCommand) Execute(_ []string) error {
+	if cmd.Rounds < 1 {
+		return errors.New("--rounds must be at least 1")
+	}
+
+	gamestats := loadGameStats()
+	gamestats.applyTheme()
+
+	parseWordLists()
+
+	rand.Seed(time.Now().UnixNano())
+
+	pattern := strings.ToUpper(cmd.Pattern)
+	if pattern == "" {
+		patterns := drillPatterns()
+		if len(patterns) == 0 {
+			return errors.New("the answer list has no families to drill, pass a --pattern")
+		}
+
+		pattern = patterns[rand.Intn(len(patterns))]
+	}
+
+	if err := checkDrillPattern(pattern); err != nil {
+		return err
+	}
+
+	family := drillFamily(pattern)
+	if len(family) < 2 {
+		return fmt.Errorf("only %d answer matches %s, try a pattern with more blanks", len(family), pattern)
+	}
+
+	rand.Shuffle(len(family), func(i, j int) {
+		family[i], family[j] = family[j], family[i]
+	})
+
+	rounds := cmd.Rounds
+	if rounds > len(family) {
+		rounds = len(family)
+	}
+
+	ty, err := tty.Open()
+	if err != nil {
+		return err
+	}
+	defer ty.Close()
+
+	session := newSession()
+
+	var stat *Screen
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+
+	go func() {
+		<-interrupt
+
+		ty.Close()
+
+		if stat != nil {
+			stat.Finish()
+		}
+
+		fmt.Printf("\nThe word was %s\n\n", word)
+		session.print()
+		os.Exit(0)
+	}()
+
+	fmt.Printf("Drilling %s, %d answers in the family\n\n", pattern, len(family))
+
+	for round := 0; round < rounds; round++ {
+		word = family[round]
+		resetGame()
+
+		fmt.Printf("    Drill %s, word %d of %d\n", pattern, round+1, rounds)
+
+		stat, err = newScreen(TotalGuesses + 1 + KeyboardRows) // +1 for "status" line
+		if err != nil {
+			return err
+		}
+
+		start := time.Now()
+		win, err := playDrillRound(ty, stat, gamestats, pattern)
+
+		stat.Finish()
+
+		if err != nil {
+			return err
+		}
+
+		took := formatClock(time.Since(start))
+
+		if win {
+			session.record(true, currentGuess+1)
+			fmt.Printf("%s in %d, %s\n\n", word, currentGuess+1, took)
+		} else {
+			session.record(false, currentGuess+1)
+			fmt.Printf("\nThe word was %s, %s\n\n", word, took)
+		}
+	}
+
+	session.print()
+
+	return nil
+}
+
+// playDrillRound plays one word of the family. Guesses are checked the same
+// way as a regular game, so hard mode applies if it's on.
+func playDrillRound(ty *tty.TTY, stat *Screen, gs *GameStats, pattern string) (win bool, err error) {
+	for i := 0; i < TotalGuesses; i++ {
+		_, _ = stat.WriteString(i, blankRow(i))
+	}
+
+	printKeyboardAt(stat, TotalGuesses+1)
+	_, _ = stat.WriteString(TotalGuesses, "The answer matches "+pattern)
+
+	check := func(guess string) string {
+		if rejection := gs.checkGuess(guess); rejection != nil {
+			return rejection.Reason
+		}
+
+		return ""
+	}
+
+	for currentGuess = 0; currentGuess < TotalGuesses; currentGuess++ {
+		guess, err := readGuess(ty, stat, currentGuess, check)
+		if err != nil {
+			return false, err
+		}
+
+		_, _ = stat.WriteString(currentGuess, formatGuess(guess, true))
+		printKeyboardAt(stat, TotalGuesses+1)
+
+		guessHistory = append(guessHistory, guess)
+
+		if guess == word {
+			return true, nil
+		}
+	}
+
+	currentGuess--
+
+	return false, nil
+}
+
+func checkDrillPattern(pattern string) error {
+	if len(pattern) != WordLength {
+		return fmt.Errorf("a pattern needs %d letters or blanks, e.g. _IGHT", WordLength)
+	}
+
+	for _, r := range pattern {
+		if r != DrillBlank && (r < 'A' || r > 'Z') {
+			return fmt.Errorf("invalid pattern %s, use letters and _ for blanks", pattern)
+		}
+	}
+
+	if !strings.ContainsRune(pattern, DrillBlank) {
+		return errors.New("a pattern needs at least one _ to fill in")
+	}
+
+	return nil
+}
+
+// drillFamily is every answer that matches a pattern.
+func drillFamily(pattern string) []string {
+	family := []string{}
+
+	for _, answer := range wordList {
+		if matchesDrillPattern(answer, pattern) {
+			family = append(family, answer)
+		}
+	}
+
+	return family
+}
+
+func matchesDrillPattern(answer string, pattern string) bool {
+	for i := 0; i < WordLength; i++ {
+		if pattern[i] != DrillBlank && pattern[i] != answer[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// drillPatterns finds the traps in the answers: every pattern with a single
+// blank that at least DrillMinFamily answers match, like _IGHT or SHA_E.
+func drillPatterns() []string {
+	counts := map[string]int{}
+
+	for _, answer := range wordList {
+		for i := 0; i < WordLength; i++ {
+			counts[answer[:i]+string(DrillBlank)+answer[i+1:]]++
+		}
+	}
+
+	patterns := []string{}
+
+	for pattern, count := range counts {
+		if count >= DrillMinFamily {
+			patterns = append(patterns, pattern)
+		}
+	}
+
+	// map order is random, sorted so the seed alone picks the pattern
+	sort.Strings(patterns)
+
+	return patterns
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"os"
+	"runtime"
+	"strings"
+)
+
+// Emoji settings, the emoji config and --emoji.
+const (
+	EmojiAuto = "auto"
+	EmojiOn   = "on"
+	EmojiOff  = "off"
+)
+
+// emojiTerminals are TERM_PROGRAM values of terminals known to draw emoji.
+var emojiTerminals = []string{"iTerm.app", "Apple_Terminal", "vscode", "WezTerm", "ghostty", "Hyper", "Tabby"}
+
+// useEmoji decides whether to print the emoji share block. --emoji wins over
+// the emoji config, and experimental_emoji_support from before there was a
+// choice still turns it on when that isn't set. Otherwise it's guessed from
+// the terminal.
+func (gs *GameStats) useEmoji() bool {
+	setting := gs.Emoji
+	if setting == "" && gs.ExperimentalEmojiSupport {
+		setting = EmojiOn
+	}
+
+	if args.Emoji != "" {
+		setting = args.Emoji
+	}
+
+	switch setting {
+	case EmojiOn:
+		return true
+	case EmojiOff:
+		return false
+	default:
+		return emojiLikely()
+	}
+}
+
+// emojiLikely guesses whether the terminal draws emoji. There's no way to ask,
+// so it goes by what's known about the terminal and the locale: the Linux
+// console and old Windows consoles can't, and anything else needs a UTF-8
+// locale to have a chance.
+func emojiLikely() bool {
+	term := os.Getenv("TERM")
+	if term == "linux" || term == "dumb" {
+		return false
+	}
+
+	if runtime.GOOS == "windows" {
+		// only Windows Terminal, the old console shows boxes
+		return os.Getenv("WT_SESSION") != ""
+	}
+
+	program := os.Getenv("TERM_PROGRAM")
+	for _, known := range emojiTerminals {
+		if program == known {
+			return true
+		}
+	}
+
+	return utf8Locale()
+}
+
+// utf8Locale checks the locale the way C programs pick it, the first of
+// LC_ALL, LC_CTYPE, and LANG that's set.
+func utf8Locale() bool {
+	for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if locale := os.Getenv(name); locale != "" {
+			locale = strings.ToLower(locale)
+			return strings.Contains(locale, "utf-8") || strings.Contains(locale, "utf8")
+		}
+	}
+
+	return false
+}
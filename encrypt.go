@@ -0,0 +1,227 @@
+package main
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/term"
+)
+
+// StatsPassphraseEnv names the environment variable an encrypted stats file's
+// passphrase can be given in instead of typing it.
+const StatsPassphraseEnv = "WORDLE_STATS_PASSPHRASE"
+
+// StatsCipher marks a stats file as encrypted and says how.
+const StatsCipher = "scrypt+aes-256-gcm"
+
+// encryptedStats is what an encrypted stats file holds in place of the stats.
+// The key is derived from the passphrase and salt with scrypt.
+type encryptedStats struct {
+	Cipher string `json:"cipher"`
+	Salt   []byte `json:"salt"`
+	Nonce  []byte `json:"nonce"`
+	Data   []byte `json:"data"`
+}
+
+// statsKey and statsSalt are kept once the passphrase has been asked for so
+// it's only asked for once, however many times the stats are saved.
+var statsKey []byte
+var statsSalt []byte
+
+// isEncryptedStats checks whether a stats file is encrypted.
+func isEncryptedStats(raw []byte) bool {
+	var envelope encryptedStats
+
+	return json.Unmarshal(raw, &envelope) == nil && envelope.Cipher != ""
+}
+
+// decryptStats decrypts a stats file if it's encrypted, asking for the
+// passphrase, and otherwise returns it as is.
+func decryptStats(raw []byte) (plain []byte, encrypted bool, err error) {
+	var envelope encryptedStats
+
+	if json.Unmarshal(raw, &envelope) != nil || envelope.Cipher == "" {
+		return raw, false, nil
+	}
+
+	if envelope.Cipher != StatsCipher {
+		return nil, true, fmt.Errorf("stats are encrypted with %s, which this version doesn't know", envelope.Cipher)
+	}
+
+	passphrase, err := statsPassphrase(false)
+	if err != nil {
+		return nil, true, err
+	}
+
+	key, err := deriveStatsKey(passphrase, envelope.Salt)
+	if err != nil {
+		return nil, true, err
+	}
+
+	gcm, err := newStatsGCM(key)
+	if err != nil {
+		return nil, true, err
+	}
+
+	plain, err = gcm.Open(nil, envelope.Nonce, envelope.Data, nil)
+	if err != nil {
+		return nil, true, errors.New("wrong passphrase for the stats file, or it's been tampered with")
+	}
+
+	statsKey, statsSalt = key, envelope.Salt
+
+	return plain, true, nil
+}
+
+// newStatsKey asks for a new passphrase to encrypt the stats with from now
+// on. It's asked for up front, before the game takes over the terminal.
+func newStatsKey() error {
+	passphrase, err := statsPassphrase(true)
+	if err != nil {
+		return err
+	}
+
+	salt := make([]byte, 16)
+
+	_, err = rand.Read(salt)
+	if err != nil {
+		return err
+	}
+
+	key, err := deriveStatsKey(passphrase, salt)
+	if err != nil {
+		return err
+	}
+
+	statsKey, statsSalt = key, salt
+
+	return nil
+}
+
+// encryptStats encrypts the stats with the key from decryptStats or
+// newStatsKey.
+func encryptStats(plain []byte) ([]byte, error) {
+	if statsKey == nil {
+		return nil, errors.New("no passphrase to encrypt the stats with")
+	}
+
+	gcm, err := newStatsGCM(statsKey)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+
+	_, err = rand.Read(nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(encryptedStats{
+		Cipher: StatsCipher,
+		Salt:   statsSalt,
+		Nonce:  nonce,
+		Data:   gcm.Seal(nil, nonce, plain, nil),
+	})
+}
+
+// deriveStatsKey stretches the passphrase into an AES-256 key.
+func deriveStatsKey(passphrase string, salt []byte) ([]byte, error) {
+	return scrypt.Key([]byte(passphrase), salt, 1<<15, 8, 1, 32)
+}
+
+func newStatsGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// statsPassphrase gets the passphrase from the environment or by asking for
+// it without echoing. A new passphrase has to be typed twice.
+func statsPassphrase(confirm bool) (string, error) {
+	if passphrase := os.Getenv(StatsPassphraseEnv); passphrase != "" {
+		return passphrase, nil
+	}
+
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return "", fmt.Errorf("no terminal to ask for the stats passphrase on, set %s to it", StatsPassphraseEnv)
+	}
+
+	prompt := "Stats passphrase: "
+	if confirm {
+		prompt = "New stats passphrase: "
+	}
+
+	fmt.Fprint(os.Stderr, prompt)
+
+	passphrase, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+
+	if err != nil {
+		return "", err
+	}
+
+	if len(passphrase) == 0 {
+		return "", errors.New("the passphrase can't be empty")
+	}
+
+	if confirm {
+		fmt.Fprint(os.Stderr, "Again: ")
+
+		again, err := term.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+
+		if err != nil {
+			return "", err
+		}
+
+		if string(again) != string(passphrase) {
+			return "", errors.New("the passphrases don't match")
+		}
+	}
+
+	return string(passphrase), nil
+}
+
+// encryptFromNowOn asks for a passphrase for --encrypt-stats, giving up on
+// the game if there isn't one rather than saving the stats in the clear.
+func (gs *GameStats) encryptFromNowOn() {
+	err := newStatsKey()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	gs.encrypted = true
+}
+
+type DecryptStatsCommand struct{}
+
+func (cmd *DecryptStatsCommand) Execute(_ []string) error {
+	gamestats := loadGameStats()
+	if !gamestats.encrypted {
+		fmt.Println("The stats file isn't encrypted")
+		return nil
+	}
+
+	gamestats.encrypted = false
+
+	err := gamestats.save()
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("The stats file is no longer encrypted")
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+)
+
+func TestEncryptStatsRoundTrip(t *testing.T) {
+	defer os.Unsetenv(StatsPassphraseEnv)
+	defer func() { statsKey, statsSalt = nil, nil }()
+
+	plain := []byte(`{"total_games":3,"wins":[0,1,2,0,0,0]}`)
+
+	_ = os.Setenv(StatsPassphraseEnv, "correct horse")
+
+	err := newStatsKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sealed, err := encryptStats(plain)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !isEncryptedStats(sealed) || bytes.Contains(sealed, []byte("total_games")) {
+		t.Fatalf("encryptStats() = %s, want it encrypted", sealed)
+	}
+
+	var tampered encryptedStats
+
+	_ = json.Unmarshal(sealed, &tampered)
+	tampered.Data[0] ^= 1
+	tamperedRaw, _ := json.Marshal(tampered)
+
+	unknown, _ := json.Marshal(encryptedStats{Cipher: "rot13", Data: []byte("x")})
+
+	tests := []struct {
+		name       string
+		passphrase string
+		raw        []byte
+		want       []byte
+		encrypted  bool
+		err        bool
+	}{
+		{"right passphrase", "correct horse", sealed, plain, true, false},
+		{"wrong passphrase", "battery staple", sealed, nil, true, true},
+		{"tampered with", "correct horse", tamperedRaw, nil, true, true},
+		{"unknown cipher", "correct horse", unknown, nil, true, true},
+		{"never encrypted", "", plain, plain, false, false},
+		{"not JSON", "", []byte("garbage"), []byte("garbage"), false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statsKey, statsSalt = nil, nil
+			_ = os.Setenv(StatsPassphraseEnv, tt.passphrase)
+
+			got, encrypted, err := decryptStats(tt.raw)
+
+			if (err != nil) != tt.err {
+				t.Fatalf("decryptStats() error = %v, want error %v", err, tt.err)
+			}
+
+			if encrypted != tt.encrypted {
+				t.Errorf("decryptStats() encrypted = %v, want %v", encrypted, tt.encrypted)
+			}
+
+			if !bytes.Equal(got, tt.want) {
+				t.Errorf("decryptStats() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncryptStatsWithoutKey(t *testing.T) {
+	statsKey, statsSalt = nil, nil
+
+	_, err := encryptStats([]byte("{}"))
+	if err == nil {
+		t.Error("encryptStats() without a passphrase succeeded, want an error")
+	}
+}
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"fmt"
+	"hash/fnv"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+)
+
+// EvalCache holds the pattern of every allowed guess against every possible
+// answer, so analysis can look patterns up instead of scoring guesses over and
+// over. It takes a second or so to score, so it's kept on disk between runs.
+type EvalCache struct {
+	guesses map[string]int
+	answers map[string]int
+	table   []Pattern // a row of answers for each guess
+}
+
+// evalCache is the loaded evaluation cache, nil until something needs it.
+var evalCache *EvalCache
+
+// loadEvalCache reads the evaluation cache for the current word lists from
+// disk, building and saving it first if it isn't there. A cache that can't
+// be saved is still returned, it'll just be built again next time. With
+// --compute-feedback there's no cache and patterns are scored on the fly.
+func loadEvalCache() (*EvalCache, error) {
+	if args.ComputeFeedback {
+		return nil, nil
+	}
+
+	guesses := make([]string, dictionary.Len())
+	for i := range guesses {
+		guesses[i] = dictionary.Word(i)
+	}
+
+	answers := sortedAnswers
+
+	ec := &EvalCache{
+		guesses: indexWords(guesses),
+		answers: indexWords(answers),
+	}
+
+	cachePath, err := evalCachePath(guesses, answers)
+	if err != nil {
+		ec.build(guesses, answers)
+		return ec, err
+	}
+
+	raw, err := ioutil.ReadFile(cachePath)
+	if err == nil && len(raw) == len(guesses)*len(answers) {
+		ec.table = make([]Pattern, len(raw))
+		for i, b := range raw {
+			ec.table[i] = Pattern(b)
+		}
+
+		return ec, nil
+	}
+
+	ec.build(guesses, answers)
+
+	return ec, ec.save(cachePath)
+}
+
+// evalCachePath names the cache file after a hash of the word lists so a
+// cache built for other lists is never used by mistake.
+func evalCachePath(guesses []string, answers []string) (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	h := fnv.New64a()
+
+	for _, list := range [][]string{guesses, answers} {
+		for _, w := range list {
+			_, _ = h.Write([]byte(w))
+		}
+
+		_, _ = h.Write([]byte{0})
+	}
+
+	return filepath.Join(dir, "wordle", fmt.Sprintf("feedback-%016x.bin", h.Sum64())), nil
+}
+
+// build scores the table a row at a time, spread across a worker per CPU.
+func (ec *EvalCache) build(guesses []string, answers []string) {
+	ec.table = make([]Pattern, len(guesses)*len(answers))
+
+	rows := make(chan int)
+	wg := sync.WaitGroup{}
+
+	for w := 0; w < runtime.NumCPU(); w++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range rows {
+				row := ec.table[i*len(answers) : (i+1)*len(answers)]
+
+				for j, answer := range answers {
+					row[j] = feedback(guesses[i], answer)
+				}
+			}
+		}()
+	}
+
+	for i := range guesses {
+		rows <- i
+	}
+
+	close(rows)
+	wg.Wait()
+}
+
+// save writes the table to cachePath and clears out any tables saved for
+// word lists that have since changed.
+func (ec *EvalCache) save(cachePath string) error {
+	err := os.MkdirAll(filepath.Dir(cachePath), 0755)
+	if err != nil {
+		return err
+	}
+
+	raw := make([]byte, len(ec.table))
+	for i, p := range ec.table {
+		raw[i] = byte(p)
+	}
+
+	// write to the side and move into place so a half written cache is never read
+	tmp := cachePath + ".tmp"
+
+	err = ioutil.WriteFile(tmp, raw, 0644)
+	if err != nil {
+		return err
+	}
+
+	err = os.Rename(tmp, cachePath)
+	if err != nil {
+		return err
+	}
+
+	// tables for word lists that have since changed are never read again
+	stale, _ := filepath.Glob(filepath.Join(filepath.Dir(cachePath), "feedback-*.bin"))
+	for _, path := range stale {
+		if path != cachePath {
+			_ = os.Remove(path)
+		}
+	}
+
+	return nil
+}
+
+// Row is the pattern of the guess against each answer, in the order Columns
+// counts in. It's nil for a guess the cache doesn't cover.
+func (ec *EvalCache) Row(guess string) []Pattern {
+	if ec == nil {
+		return nil
+	}
+
+	i, ok := ec.guesses[guess]
+	if !ok {
+		return nil
+	}
+
+	return ec.table[i*len(ec.answers) : (i+1)*len(ec.answers)]
+}
+
+// Columns finds each answer's place in a row, so the hot loops can look
+// patterns up by index instead of by word. It's nil if any of them isn't an
+// answer the cache covers.
+func (ec *EvalCache) Columns(answers []string) []int {
+	if ec == nil {
+		return nil
+	}
+
+	columns := make([]int, len(answers))
+
+	for i, answer := range answers {
+		j, ok := ec.answers[answer]
+		if !ok {
+			return nil
+		}
+
+		columns[i] = j
+	}
+
+	return columns
+}
+
+func indexWords(words []string) map[string]int {
+	index := make(map[string]int, len(words))
+	for i, w := range words {
+		index[w] = i
+	}
+
+	return index
+}
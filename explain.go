@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+var ordinals = []string{"first", "second", "third", "fourth", "fifth"}
+var numberWords = []string{"no", "one", "two", "three", "four", "five"}
+
+// explainDuplicates describes why each repeated letter in a scored guess got
+// the color it did. It returns an empty string when the guess has no repeated
+// letters.
+func explainDuplicates(guess string, answer string, hints []KeyHint) string {
+	inGuess := mapString(guess)
+	inAnswer := mapString(answer)
+
+	reasons := []string{}
+	seen := map[byte]bool{}
+
+	for i := range guess {
+		letter := guess[i]
+		if seen[letter] || inGuess[letter] < 2 {
+			continue
+		}
+
+		seen[letter] = true
+		count := inAnswer[letter]
+
+		if count == 0 {
+			reasons = append(reasons, fmt.Sprintf("every %c red because answer has no %c", letter, letter))
+			continue
+		}
+
+		occurrence := 0
+		allScored := true
+
+		for j := range guess {
+			if guess[j] != letter {
+				continue
+			}
+
+			if hints[j] == KeyHintNotInWord {
+				allScored = false
+				reasons = append(reasons, fmt.Sprintf("%s %c red because answer has only %s", ordinals[occurrence], letter, countLetter(count, letter)))
+			}
+
+			occurrence++
+		}
+
+		if allScored {
+			reasons = append(reasons, fmt.Sprintf("every %c colored because answer has %s", letter, countLetter(count, letter)))
+		}
+	}
+
+	return strings.Join(reasons, "; ")
+}
+
+// countLetter spells out a letter count, e.g. "one E" or "two E's".
+func countLetter(count int, letter byte) string {
+	if count == 1 {
+		return fmt.Sprintf("one %c", letter)
+	}
+
+	return fmt.Sprintf("%s %c's", numberWords[count], letter)
+}
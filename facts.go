@@ -0,0 +1,30 @@
+package main
+
+import (
+	"bufio"
+	_ "embed"
+	"strings"
+)
+
+//go:embed facts.txt
+var rawFacts string
+
+var facts map[string]string
+
+// wordFact looks up the fun fact or etymology for an answer, if there is one.
+// Each line of facts.txt is a word and then its fact.
+func wordFact(w string) string {
+	if facts == nil {
+		facts = map[string]string{}
+
+		scanner := bufio.NewScanner(strings.NewReader(rawFacts))
+		for scanner.Scan() {
+			fields := strings.SplitN(strings.TrimSpace(scanner.Text()), " ", 2)
+			if len(fields) == 2 {
+				facts[fields[0]] = fields[1]
+			}
+		}
+	}
+
+	return facts[w]
+}
@@ -0,0 +1,65 @@
+package main
+
+// Pattern is the feedback a guess gets packed into a single byte, one base 3
+// digit per letter with the first letter in the lowest digit: 0 for not in the
+// word, 1 for somewhere else, 2 for located.
+type Pattern uint8
+
+// PatternCount is how many distinct patterns there are, 3 to the 5th.
+const PatternCount = 243
+
+// PatternSolved is the pattern of a guess that is the answer.
+const PatternSolved Pattern = PatternCount - 1
+
+// feedback scores a guess like scoreGuess does but without allocating, for
+// the solver's hot loops.
+func feedback(guess string, answer string) Pattern {
+	var unmatched [26]byte
+
+	for i := 0; i < WordLength; i++ {
+		if guess[i] != answer[i] {
+			unmatched[answer[i]-'A']++
+		}
+	}
+
+	pattern := Pattern(0)
+	weight := Pattern(1)
+
+	for i := 0; i < WordLength; i++ {
+		switch {
+		case guess[i] == answer[i]:
+			pattern += 2 * weight
+		case unmatched[guess[i]-'A'] > 0:
+			unmatched[guess[i]-'A']--
+			pattern += weight
+		}
+
+		weight *= 3
+	}
+
+	return pattern
+}
+
+// Hints unpacks a pattern into the hint for each letter.
+func (p Pattern) Hints() []KeyHint {
+	hints := make([]KeyHint, WordLength)
+
+	for i := range hints {
+		hints[i] = []KeyHint{KeyHintNotInWord, KeyHintSomewhere, KeyHintLocated}[p%3]
+		p /= 3
+	}
+
+	return hints
+}
+
+// String spells the pattern out a letter at a time, e.g. "02110".
+func (p Pattern) String() string {
+	digits := make([]byte, WordLength)
+
+	for i := range digits {
+		digits[i] = '0' + byte(p%3)
+		p /= 3
+	}
+
+	return string(digits)
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFeedback(t *testing.T) {
+	tests := []struct {
+		guess  string
+		answer string
+		want   string
+	}{
+		{"CRANE", "CRANE", "22222"},
+		{"SLATE", "CRANE", "00202"},
+		{"BUMPY", "CRANE", "00000"},
+		{"SPEED", "ABIDE", "00101"}, // only one E is yellow, the answer has one
+		{"ALLOT", "LOYAL", "11110"}, // both L's are yellow, the answer has two
+		{"EERIE", "THERE", "10102"}, // the green E uses up one of the two
+		{"LLAMA", "HELLO", "11000"}, // more L's guessed than the answer has
+		{"ABBEY", "BABES", "11220"}, // the located B doesn't count again
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.guess+"/"+tt.answer, func(t *testing.T) {
+			got := feedback(tt.guess, tt.answer)
+
+			if got.String() != tt.want {
+				t.Errorf("feedback(%q, %q) = %s, want %s", tt.guess, tt.answer, got, tt.want)
+			}
+
+			if hints := got.Hints(); !reflect.DeepEqual(hints, scoreGuess(tt.guess, tt.answer)) {
+				t.Errorf("feedback(%q, %q).Hints() = %v, scoreGuess gives %v", tt.guess, tt.answer, hints, scoreGuess(tt.guess, tt.answer))
+			}
+		})
+	}
+}
+
+func TestPatternString(t *testing.T) {
+	tests := []struct {
+		pattern Pattern
+		want    string
+	}{
+		{0, "00000"},
+		{1, "10000"},
+		{2, "20000"},
+		{3, "01000"},
+		{81, "00001"},
+		{PatternSolved, "22222"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.pattern.String(); got != tt.want {
+			t.Errorf("Pattern(%d).String() = %s, want %s", tt.pattern, got, tt.want)
+		}
+	}
+}
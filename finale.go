@@ -0,0 +1,165 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-tty"
+)
+
+const (
+	FinaleRevealed    = 3
+	FinalePoints      = 10
+	FinaleStreakBonus = 5
+)
+
+type FinaleStats struct {
+	Games      int `json:"games"`
+	Wins       int `json:"wins"`
+	Points     int `json:"points"`
+	Streak     int `json:"streak"`
+	BestStreak int `json:"best_streak"`
+}
+
+type FinaleCommand struct {
+	Stats bool `short:"s" long:"stats" description:"Print finale stats without playing"`
+}
+
+func (cmd *FinaleCommand) Execute(_ []string) error {
+	gamestats := loadGameStats()
+	gamestats.applyTheme()
+
+	if cmd.Stats {
+		gamestats.Finale.print()
+		return nil
+	}
+
+	parseWordLists()
+
+	rand.Seed(time.Now().UnixNano())
+	word = wordList[rand.Intn(len(wordList))]
+
+	resetGame()
+
+	// reveal some of the letters, guesses have to use them like in hard mode
+	for _, i := range rand.Perm(WordLength)[:FinaleRevealed] {
+		discovered[i] = true
+	}
+
+	fmt.Println("  One Guess Finale!")
+
+	ty, err := tty.Open()
+	if err != nil {
+		return err
+	}
+
+	tyOpen := true
+	defer func() {
+		if tyOpen {
+			ty.Close()
+		}
+	}()
+
+	stat, err := newScreen(3) // revealed letters, guess, status
+	if err != nil {
+		return err
+	}
+	defer stat.Finish()
+
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, os.Interrupt)
+
+	go func() {
+		<-c
+
+		ty.Close()
+		stat.Finish()
+		os.Exit(0)
+	}()
+
+	_, _ = stat.WriteString(0, revealedLetters())
+	guess, err := readGuess(ty, stat, 1, func(guess string) string {
+		if reason := mustBeWord(guess); reason != "" {
+			return reason
+		}
+
+		if !hardModeEnforcement(guess) {
+			return "must use revealed letters"
+		}
+
+		return ""
+	})
+	if err != nil {
+		return err
+	}
+
+	_, _ = stat.WriteString(1, formatGuess(guess, true))
+
+	stat.Finish()
+	ty.Close()
+	tyOpen = false
+
+	win := guess == word
+	points := gamestats.Finale.record(win)
+	_ = gamestats.save()
+
+	if win {
+		fmt.Printf("You win! +%d points\n\n", points)
+	} else {
+		fmt.Printf("The word was %s\n\n", word)
+	}
+
+	gamestats.Finale.print()
+
+	return nil
+}
+
+// revealedLetters shows the letters given away at the start of a finale.
+func revealedLetters() string {
+	slots := make([]string, WordLength)
+
+	for i := range slots {
+		slots[i] = "_"
+		if discovered[i] {
+			slots[i] = tileColorFns[KeyHintLocated](string(word[i]))
+		}
+	}
+
+	return "     " + strings.Join(slots, " ")
+}
+
+// record counts a finale and returns the points it earned. Every win in a row
+// is worth a bit more than the last.
+func (fs *FinaleStats) record(win bool) int {
+	fs.Games++
+
+	if !win {
+		fs.Streak = 0
+		return 0
+	}
+
+	points := FinalePoints + FinaleStreakBonus*fs.Streak
+
+	fs.Wins++
+	fs.Points += points
+	fs.Streak++
+
+	if fs.Streak > fs.BestStreak {
+		fs.BestStreak = fs.Streak
+	}
+
+	return points
+}
+
+func (fs *FinaleStats) print() {
+	fmt.Print("Finale Stats\n\n")
+	fmt.Printf("   Total Games: %d\n", fs.Games)
+	fmt.Printf("          Wins: %d\n", fs.Wins)
+	fmt.Printf("        Points: %d\n", fs.Points)
+	fmt.Printf("Current Streak: %d\n", fs.Streak)
+	fmt.Printf("   Best Streak: %d\n", fs.BestStreak)
+}
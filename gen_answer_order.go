@@ -0,0 +1,57 @@
+//go:build ignore
+// +build ignore
+
+// gen_answer_order writes answer_order.bin, the order that sorts the answers,
+// so the dictionary doesn't have to sort them on every run. Run it with go
+// generate whenever good_words.txt changes.
+package main
+
+import (
+	"bufio"
+	"encoding/binary"
+	"log"
+	"os"
+	"sort"
+	"strings"
+)
+
+func main() {
+	raw, err := os.ReadFile("good_words.txt")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	answers := []string{}
+
+	scanner := bufio.NewScanner(strings.NewReader(string(raw)))
+	for scanner.Scan() {
+		// same clean up as normalizeWordList, so the order matches the list
+		w := strings.ToUpper(strings.TrimSpace(strings.TrimPrefix(scanner.Text(), "\ufeff")))
+		if w != "" {
+			answers = append(answers, w)
+		}
+	}
+
+	writeAnswerOrder(answers)
+}
+
+// writeAnswerOrder writes the index into the answer list of each answer in
+// alphabetical order, two bytes apiece.
+func writeAnswerOrder(answers []string) {
+	order := make([]int, len(answers))
+	for i := range order {
+		order[i] = i
+	}
+
+	sort.SliceStable(order, func(i, j int) bool { return answers[order[i]] < answers[order[j]] })
+
+	raw := make([]byte, 2*len(order))
+	for i, j := range order {
+		binary.BigEndian.PutUint16(raw[2*i:], uint16(j))
+	}
+
+	err := os.WriteFile("answer_order.bin", raw, 0644)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
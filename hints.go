@@ -0,0 +1,44 @@
+package main
+
+import (
+	"bufio"
+	_ "embed"
+	"strings"
+)
+
+const (
+	HintCategory = "category"
+
+	// CategoryHintAfter is how many failed guesses it takes before the
+	// category hint is offered.
+	CategoryHintAfter = 3
+
+	KeyCategoryHint = '!'
+)
+
+//go:embed categories.txt
+var rawCategories string
+
+var categories map[string]string
+
+// wordCategory looks up the category an answer is tagged with, if any.
+func wordCategory(w string) string {
+	if categories == nil {
+		categories = map[string]string{}
+
+		scanner := bufio.NewScanner(strings.NewReader(rawCategories))
+		for scanner.Scan() {
+			fields := strings.Fields(scanner.Text())
+			if len(fields) == 2 {
+				categories[fields[0]] = fields[1]
+			}
+		}
+	}
+
+	return categories[w]
+}
+
+// categoryHintReady checks if the category hint can be offered yet.
+func categoryHintReady() bool {
+	return args.Hints == HintCategory && len(guessHistory) >= CategoryHintAfter && wordCategory(word) != ""
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"sort"
+	"time"
+)
+
+// Game modes stored with each game in the history.
+const (
+	GameModeDaily    = "daily"
+	GameModeGroup    = "group"
+	GameModeRandom   = "random"
+	GameModeAdaptive = "adaptive"
+)
+
+// HistoryVersion is the current layout of the history. Stats files saved by
+// older versions are migrated when they're loaded.
+const HistoryVersion = 2
+
+// knownGameMode checks a mode read back from outside the stats file.
+func knownGameMode(mode string) bool {
+	switch mode {
+	case GameModeDaily, GameModeGroup, GameModeRandom, GameModeAdaptive:
+		return true
+	}
+
+	return false
+}
+
+// gameMode is the mode of the game being played.
+var gameMode = GameModeRandom
+
+// GameRecord is a single finished game kept in the stats file.
+type GameRecord struct {
+	Date       time.Time `json:"date"`
+	Answer     string    `json:"answer"`
+	Guesses    []string  `json:"guesses"`
+	Seconds    []float64 `json:"seconds,omitempty"`
+	Won        bool      `json:"won"`
+	HardMode   bool      `json:"hard_mode"`
+	Group      string    `json:"group,omitempty"`
+	Number     int       `json:"number,omitempty"`
+	Imported   bool      `json:"imported,omitempty"`
+	Pattern    []string  `json:"pattern,omitempty"`
+	Mode       string    `json:"mode,omitempty"`
+	Flags      []string  `json:"flags,omitempty"`
+	Nonce      string    `json:"nonce,omitempty"`
+	Luck       *int      `json:"luck,omitempty"`
+	Difficulty *int      `json:"difficulty,omitempty"`
+}
+
+// recordGame appends the game that just finished to the history.
+func (gs *GameStats) recordGame(win bool) {
+	guesses := make([]string, len(guessHistory))
+	copy(guesses, guessHistory)
+
+	seconds := make([]float64, len(guessTimes))
+	for i, d := range guessTimes {
+		seconds[i] = d.Seconds()
+	}
+
+	gs.History = append(gs.History, GameRecord{
+		Date:     time.Now(),
+		Answer:   word,
+		Guesses:  guesses,
+		Seconds:  seconds,
+		Won:      win,
+		HardMode: args.HardMode,
+		Group:    args.Group,
+		Mode:     gameMode,
+		Flags:    gameFlags(),
+	})
+
+	if luck := gameLuck(guesses, word); luck >= 0 {
+		gs.History[len(gs.History)-1].Luck = &luck
+	}
+
+	if gameMode == GameModeDaily {
+		difficulty := answerDifficulty(word)
+		gs.History[len(gs.History)-1].Difficulty = &difficulty
+	}
+
+	if gameMode == GameModeDaily || transcriptNonce != "" {
+		gs.History[len(gs.History)-1].Number = gs.puzzleNumber()
+	}
+
+	if transcriptNonce != "" {
+		gs.History[len(gs.History)-1].Nonce = transcriptNonce
+	}
+}
+
+// gameFlags lists the options that changed how the current game played, other
+// than hard mode which has always been recorded on its own.
+func gameFlags() []string {
+	flags := []string{}
+
+	for flag, set := range map[string]bool{
+		"adaptive":      args.Adaptive,
+		"assisted":      assisted,
+		"explain":       args.Explain,
+		"hints":         args.Hints != "",
+		"novice":        args.Novice,
+		"no-repeats":    args.NoRepeats,
+		"random-opener": args.RandomOpener,
+		"slow":          args.Slow > 0,
+		"trainer":       args.Trainer && (gameMode == GameModeRandom || gameMode == GameModeAdaptive),
+		"typing":        args.Typing,
+	} {
+		if set {
+			flags = append(flags, flag)
+		}
+	}
+
+	sort.Strings(flags)
+
+	return flags
+}
+
+// assistedFlags is the flags of a game whose only known option is whether it
+// was solver assisted.
+func assistedFlags(assisted bool) []string {
+	if assisted {
+		return []string{"assisted"}
+	}
+
+	return nil
+}
+
+// migrateHistory fills in the mode of games saved before it was recorded.
+// Games with a group were group dailies and imported games came from the
+// website's dailies. Anything else was a daily if its answer was that day's
+// daily answer. Adaptive games saved before they had their own mode are moved
+// out of the regular stats, though the streak they touched can't be undone.
+func (gs *GameStats) migrateHistory() {
+	if gs.HistoryVersion >= HistoryVersion {
+		return
+	}
+
+	parseWordLists()
+
+	for i := range gs.History {
+		game := &gs.History[i]
+
+		switch {
+		case game.Mode == "" && game.Group != "":
+			game.Mode = GameModeGroup
+		case game.Mode == "" && (game.Imported || gs.wasDailyAnswer(game)):
+			game.Mode = GameModeDaily
+		case game.Mode == "":
+			game.Mode = GameModeRandom
+		}
+
+		if game.Mode == GameModeRandom && hasFlag(game.Flags, "adaptive") && !hasFlag(game.Flags, "assisted") {
+			game.Mode = GameModeAdaptive
+			gs.uncountGame(game)
+		}
+	}
+
+	gs.HistoryVersion = HistoryVersion
+}
+
+// uncountGame moves an adaptive game already counted in the regular stats
+// into the adaptive bucket.
+func (gs *GameStats) uncountGame(game *GameRecord) {
+	// countAdaptive takes back the game the first guess counted
+	gs.countAdaptive(game.HardMode, game.Won, len(game.Guesses))
+
+	wins := gs.Wins
+	if game.HardMode {
+		wins = gs.HardWins
+	}
+
+	if game.Won && len(game.Guesses) > 0 && len(game.Guesses) <= len(wins) && wins[len(game.Guesses)-1] > 0 {
+		wins[len(game.Guesses)-1]--
+	}
+}
+
+// hasFlag checks whether a game was recorded with the given flag.
+func hasFlag(flags []string, flag string) bool {
+	for _, f := range flags {
+		if f == flag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// wasDailyAnswer checks whether a game's answer was the daily on the day it
+// was played, or the day before for dailies that ran past midnight.
+func (gs *GameStats) wasDailyAnswer(game *GameRecord) bool {
+	_, ok := gs.answerDay(game)
+
+	return ok
+}
+
+// answerDay finds the day a game's answer was the daily, the day it was played
+// or the day before for dailies that ran past midnight. The daily is looked up
+// through dailyIndex so the shuffled rotation is followed too.
+func (gs *GameStats) answerDay(game *GameRecord) (int, bool) {
+	parseWordLists()
+
+	day := int(game.Date.Sub(dailyEpoch).Hours() / 24)
+
+	for _, offset := range []int{day, day - 1} {
+		if offset >= 0 && wordList[gs.dailyIndex(offset)] == game.Answer {
+			return offset, true
+		}
+	}
+
+	return day, false
+}
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var shareHeader = regexp.MustCompile(`^Wordle\s+([\d,.]+)\s+([1-6X])/6(\*?)`)
+
+type ImportShareCommand struct{}
+
+func (cmd *ImportShareCommand) Execute(_ []string) error {
+	gamestats := loadGameStats()
+
+	imported, skipped, err := gamestats.importShares(bufio.NewScanner(os.Stdin))
+	if err != nil {
+		return err
+	}
+
+	err = gamestats.save()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Imported %d game(s)", imported)
+
+	if skipped > 0 {
+		fmt.Printf(", skipped %d already in your history", skipped)
+	}
+
+	fmt.Println()
+
+	return nil
+}
+
+// importShares reads pasted share blocks, like the ones the official game
+// copies to the clipboard, and records each one as a finished game.
+func (gs *GameStats) importShares(scanner *bufio.Scanner) (imported int, skipped int, err error) {
+	var pending *GameRecord
+
+	finish := func() {
+		if pending == nil {
+			return
+		}
+
+		if gs.hasDaily(pending.Number) {
+			skipped++
+		} else {
+			gs.addImported(pending)
+			imported++
+		}
+
+		pending = nil
+	}
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if match := shareHeader.FindStringSubmatch(line); match != nil {
+			finish()
+
+			number, err := strconv.Atoi(strings.NewReplacer(",", "", ".", "").Replace(match[1]))
+			if err != nil {
+				return imported, skipped, fmt.Errorf("invalid puzzle number %q", match[1])
+			}
+
+			pending = &GameRecord{
+				Date:     dailyEpoch.AddDate(0, 0, number-gs.PuzzleNumberOffset),
+				Number:   number,
+				Won:      match[2] != "X",
+				HardMode: match[3] == "*",
+				Imported: true,
+				Mode:     GameModeDaily,
+			}
+
+			continue
+		}
+
+		if pending != nil && isShareRow(line) {
+			pending.Pattern = append(pending.Pattern, line)
+		}
+	}
+
+	finish()
+
+	if imported > 0 {
+		gs.recomputeStreaks()
+	}
+
+	return imported, skipped, scanner.Err()
+}
+
+// isShareRow checks if a line is a row of emoji squares.
+func isShareRow(line string) bool {
+	squares := 0
+
+	for _, r := range line {
+		switch r {
+		case EmojiNotInWord, EmojiSomewhere, EmojiLocated, '⬜', '🟧', '🟦':
+			squares++
+		default:
+			return false
+		}
+	}
+
+	return squares == WordLength
+}
+
+// hasDaily checks whether the daily with this puzzle number is already in the
+// history, whether it was imported or played here, or compacted since.
+func (gs *GameStats) hasDaily(number int) bool {
+	for i := range gs.History {
+		if gs.dailyNumber(&gs.History[i]) == number {
+			return true
+		}
+	}
+
+	for _, summary := range gs.Compacted {
+		for _, compacted := range summary.Numbers {
+			if compacted == number {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// dailyNumber is the puzzle number of a daily in the history, or 0 for any
+// other game. Dailies saved before the number was recorded go by the day they
+// were played, or the day before for one that ran past midnight.
+func (gs *GameStats) dailyNumber(game *GameRecord) int {
+	if game.Mode != GameModeDaily {
+		return 0
+	}
+
+	if game.Number != 0 {
+		return game.Number
+	}
+
+	day, _ := gs.answerDay(game)
+
+	return day + gs.PuzzleNumberOffset
+}
+
+// recomputeStreaks works the streaks out again from the history in the order
+// the games were played, since imported games can land in the middle of it.
+// Solver assisted and adaptive games never counted toward the streak. A
+// streak that runs back past the start of the history can't be seen, so the
+// streaks are never lowered below what was kept unless the history shows a
+// loss that ended them.
+func (gs *GameStats) recomputeStreaks() {
+	games := []GameRecord{}
+
+	for _, game := range gs.History {
+		if game.Mode != GameModeAdaptive && !hasFlag(game.Flags, "assisted") {
+			games = append(games, game)
+		}
+	}
+
+	sort.SliceStable(games, func(i, j int) bool {
+		return games[i].Date.Before(games[j].Date)
+	})
+
+	streak := 0
+	lost := false
+
+	for _, game := range games {
+		if game.Won {
+			streak++
+		} else {
+			streak = 0
+			lost = true
+		}
+
+		if streak > gs.BestStreak {
+			gs.BestStreak = streak
+		}
+	}
+
+	if lost || streak > gs.Streak {
+		gs.Streak = streak
+	}
+}
+
+// addImported records an imported game and counts it toward the stats.
+func (gs *GameStats) addImported(record *GameRecord) {
+	gs.History = append(gs.History, *record)
+
+	if record.HardMode {
+		gs.TotalHardGames++
+	} else {
+		gs.TotalGames++
+	}
+
+	if !record.Won || len(record.Pattern) == 0 || len(record.Pattern) > TotalGuesses {
+		return
+	}
+
+	if record.HardMode {
+		gs.HardWins[len(record.Pattern)-1]++
+	} else {
+		gs.Wins[len(record.Pattern)-1]++
+	}
+}
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"bufio"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestImportShares(t *testing.T) {
+	tests := []struct {
+		name      string
+		stats     GameStats
+		paste     string
+		imported  int
+		skipped   int
+		games     int
+		hardGames int
+		wins      []int
+		hardWins  []int
+	}{
+		{
+			name:     "win",
+			paste:    "Wordle 1,000 3/6\n\n⬛🟨⬛⬛⬛\n⬛🟩🟨⬛⬛\n🟩🟩🟩🟩🟩\n",
+			imported: 1,
+			games:    1,
+			wins:     []int{0, 0, 1, 0, 0, 0},
+			hardWins: []int{0, 0, 0, 0, 0, 0},
+		},
+		{
+			name:      "hard mode loss",
+			paste:     "Wordle 1000 X/6*\n⬛⬛⬛⬛⬛\n⬛⬛⬛⬛⬛\n⬛⬛⬛⬛⬛\n⬛⬛⬛⬛⬛\n⬛⬛⬛⬛⬛\n⬛⬛⬛⬛⬛\n",
+			imported:  1,
+			hardGames: 1,
+			wins:      []int{0, 0, 0, 0, 0, 0},
+			hardWins:  []int{0, 0, 0, 0, 0, 0},
+		},
+		{
+			name:     "high contrast squares and chatter",
+			paste:    "look at this\nWordle 1000 2/6\n⬜🟦⬜⬜🟧\n🟧🟧🟧🟧🟧\nnice one\n",
+			imported: 1,
+			games:    1,
+			wins:     []int{0, 1, 0, 0, 0, 0},
+			hardWins: []int{0, 0, 0, 0, 0, 0},
+		},
+		{
+			name:     "pasted twice",
+			paste:    "Wordle 1000 1/6\n🟩🟩🟩🟩🟩\nWordle 1000 1/6\n🟩🟩🟩🟩🟩\n",
+			imported: 1,
+			skipped:  1,
+			games:    1,
+			wins:     []int{1, 0, 0, 0, 0, 0},
+			hardWins: []int{0, 0, 0, 0, 0, 0},
+		},
+		{
+			name: "played here",
+			stats: GameStats{History: []GameRecord{
+				{Date: dailyEpoch.AddDate(0, 0, 1000), Number: 1000, Mode: GameModeDaily, Won: true},
+			}},
+			paste:    "Wordle 1000 1/6\n🟩🟩🟩🟩🟩\n",
+			skipped:  1,
+			wins:     []int{0, 0, 0, 0, 0, 0},
+			hardWins: []int{0, 0, 0, 0, 0, 0},
+		},
+		{
+			name: "played here before numbers were kept",
+			stats: GameStats{History: []GameRecord{
+				{Date: dailyEpoch.AddDate(0, 0, 1000).Add(15 * time.Hour), Mode: GameModeDaily, Won: true},
+			}},
+			paste:    "Wordle 1000 1/6\n🟩🟩🟩🟩🟩\n",
+			skipped:  1,
+			wins:     []int{0, 0, 0, 0, 0, 0},
+			hardWins: []int{0, 0, 0, 0, 0, 0},
+		},
+		{
+			name: "a random game the same day",
+			stats: GameStats{History: []GameRecord{
+				{Date: dailyEpoch.AddDate(0, 0, 1000), Mode: GameModeRandom, Won: true},
+			}},
+			paste:    "Wordle 1000 1/6\n🟩🟩🟩🟩🟩\n",
+			imported: 1,
+			games:    1,
+			wins:     []int{1, 0, 0, 0, 0, 0},
+			hardWins: []int{0, 0, 0, 0, 0, 0},
+		},
+		{
+			name:     "compacted",
+			stats:    GameStats{Compacted: []HistorySummary{{Month: "2024-03", Mode: GameModeDaily, Numbers: []int{999, 1000}}}},
+			paste:    "Wordle 1000 1/6\n🟩🟩🟩🟩🟩\n",
+			skipped:  1,
+			wins:     []int{0, 0, 0, 0, 0, 0},
+			hardWins: []int{0, 0, 0, 0, 0, 0},
+		},
+		{
+			name:     "no header",
+			paste:    "🟩🟩🟩🟩🟩\n",
+			wins:     []int{0, 0, 0, 0, 0, 0},
+			hardWins: []int{0, 0, 0, 0, 0, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gs := tt.stats
+			gs.Wins = make([]int, TotalGuesses)
+			gs.HardWins = make([]int, TotalGuesses)
+
+			imported, skipped, err := gs.importShares(bufio.NewScanner(strings.NewReader(tt.paste)))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if imported != tt.imported || skipped != tt.skipped {
+				t.Errorf("imported %d and skipped %d, want %d and %d", imported, skipped, tt.imported, tt.skipped)
+			}
+
+			if gs.TotalGames != tt.games || gs.TotalHardGames != tt.hardGames {
+				t.Errorf("counted %d games and %d hard games, want %d and %d", gs.TotalGames, gs.TotalHardGames, tt.games, tt.hardGames)
+			}
+
+			if !reflect.DeepEqual(gs.Wins, tt.wins) || !reflect.DeepEqual(gs.HardWins, tt.hardWins) {
+				t.Errorf("wins %v and hard wins %v, want %v and %v", gs.Wins, gs.HardWins, tt.wins, tt.hardWins)
+			}
+		})
+	}
+}
+
+func TestImportSharesStreaks(t *testing.T) {
+	tests := []struct {
+		name   string
+		stats  GameStats
+		paste  string
+		streak int
+		best   int
+	}{
+		{
+			name:   "wins then a loss",
+			paste:  "Wordle 1000 3/6\nWordle 1001 4/6\nWordle 1002 X/6\n",
+			streak: 0,
+			best:   2,
+		},
+		{
+			name:   "pasted out of order",
+			paste:  "Wordle 1002 3/6\nWordle 1000 X/6\nWordle 1001 4/6\n",
+			streak: 2,
+			best:   2,
+		},
+		{
+			name: "a loss before what was played here",
+			stats: GameStats{Streak: 1, BestStreak: 1, History: []GameRecord{
+				{Date: dailyEpoch.AddDate(0, 0, 1003), Number: 1003, Mode: GameModeDaily, Won: true},
+			}},
+			paste:  "Wordle 1001 3/6\nWordle 1002 X/6\n",
+			streak: 1,
+			best:   1,
+		},
+		{
+			name: "a loss after what was played here",
+			stats: GameStats{Streak: 1, BestStreak: 1, History: []GameRecord{
+				{Date: dailyEpoch.AddDate(0, 0, 1003), Number: 1003, Mode: GameModeDaily, Won: true},
+			}},
+			paste:  "Wordle 1004 X/6\n",
+			streak: 0,
+			best:   1,
+		},
+		{
+			name:   "a streak from before the history",
+			stats:  GameStats{Streak: 10, BestStreak: 12},
+			paste:  "Wordle 1000 3/6\n",
+			streak: 10,
+			best:   12,
+		},
+		{
+			name: "assisted and adaptive games don't count",
+			stats: GameStats{History: []GameRecord{
+				{Date: dailyEpoch.AddDate(0, 0, 1001), Mode: GameModeRandom, Flags: []string{"assisted"}},
+				{Date: dailyEpoch.AddDate(0, 0, 1001), Mode: GameModeAdaptive},
+			}},
+			paste:  "Wordle 1000 3/6\nWordle 1002 3/6\n",
+			streak: 2,
+			best:   2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gs := tt.stats
+			gs.Wins = make([]int, TotalGuesses)
+			gs.HardWins = make([]int, TotalGuesses)
+
+			_, _, err := gs.importShares(bufio.NewScanner(strings.NewReader(tt.paste)))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if gs.Streak != tt.streak || gs.BestStreak != tt.best {
+				t.Errorf("streak %d and best %d, want %d and %d", gs.Streak, gs.BestStreak, tt.streak, tt.best)
+			}
+		})
+	}
+}
+
+func TestIsShareRow(t *testing.T) {
+	tests := []struct {
+		line string
+		want bool
+	}{
+		{"🟩🟩🟩🟩🟩", true},
+		{"⬛🟨⬛⬛🟩", true},
+		{"⬜🟦⬜⬜🟧", true},
+		{"🟩🟩🟩🟩", false},
+		{"🟩🟩🟩🟩🟩🟩", false},
+		{"🟩🟩x🟩🟩", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := isShareRow(tt.line); got != tt.want {
+			t.Errorf("isShareRow(%q) = %v, want %v", tt.line, got, tt.want)
+		}
+	}
+}
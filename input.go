@@ -0,0 +1,115 @@
+package main
+
+import (
+	"unicode"
+
+	"github.com/mattn/go-tty"
+)
+
+// inputLayouts translate what the terminal delivers into the letter printed
+// on the key that was pressed. The Latin layouts are for hardware whose labels
+// don't match the layout the system is set to, so keys arrive as if they were
+// on a QWERTY keyboard. The Cyrillic layout maps keys by position so a
+// ЙЦУКЕН keyboard can be used without switching the system layout.
+var inputLayouts = map[string]map[rune]rune{
+	"qwerty": {},
+	"azerty": {
+		'Q': 'A', 'W': 'Z', 'A': 'Q', 'Z': 'W', ';': 'M', 'M': ',',
+	},
+	"qwertz": {
+		'Y': 'Z', 'Z': 'Y',
+	},
+	"dvorak": {
+		'Q': '\'', 'W': ',', 'E': '.', 'R': 'P', 'T': 'Y', 'Y': 'F', 'U': 'G', 'I': 'C', 'O': 'R', 'P': 'L',
+		'S': 'O', 'D': 'E', 'F': 'U', 'G': 'I', 'H': 'D', 'J': 'H', 'K': 'T', 'L': 'N', ';': 'S',
+		'Z': ';', 'X': 'Q', 'C': 'J', 'V': 'K', 'B': 'X', 'N': 'B', ',': 'W', '.': 'V', '/': 'Z',
+	},
+	"cyrillic": {
+		'Й': 'Q', 'Ц': 'W', 'У': 'E', 'К': 'R', 'Е': 'T', 'Н': 'Y', 'Г': 'U', 'Ш': 'I', 'Щ': 'O', 'З': 'P',
+		'Ф': 'A', 'Ы': 'S', 'В': 'D', 'А': 'F', 'П': 'G', 'Р': 'H', 'О': 'J', 'Л': 'K', 'Д': 'L',
+		'Я': 'Z', 'Ч': 'X', 'С': 'C', 'М': 'V', 'И': 'B', 'Т': 'N', 'Ь': 'M',
+	},
+}
+
+// translateInput upper cases a key and maps it through the --input-layout.
+func translateInput(pressed rune) rune {
+	pressed = unicode.ToUpper(pressed)
+
+	if translated, ok := inputLayouts[args.InputLayout][pressed]; ok {
+		return translated
+	}
+
+	return pressed
+}
+
+// isGuessLetter is whether a translated key can go in a guess. Only A to Z
+// are in the word lists, so a key the layout doesn't map, like Ж on the
+// Cyrillic layout or an accented letter, is ignored rather than typed.
+func isGuessLetter(pressed rune) bool {
+	return pressed >= 'A' && pressed <= 'Z'
+}
+
+// untranslateInput is the key that translateInput turns into a letter, for
+// letters that didn't come from the keyboard, like a click on the on-screen
+// keyboard.
+func untranslateInput(letter rune) rune {
+	for key, translated := range inputLayouts[args.InputLayout] {
+		if translated == letter {
+			return key
+		}
+	}
+
+	return letter
+}
+
+// readGuess reads keys into a guess on the given screen line until it's
+// submitted with Enter. When check has a reason to reject the guess, the
+// reason is shown next to it and it has to be fixed before it's accepted.
+func readGuess(ty *tty.TTY, stat *Screen, line int, check func(guess string) string) (string, error) {
+	guess := ""
+
+	_, _ = stat.WriteString(line, formatGuess(guess, false))
+
+	for {
+		stat.Flush()
+
+		pressed, err := ty.ReadRune()
+		if err != nil {
+			return "", err
+		}
+
+		stat.Begin()
+
+		pressed = translateInput(pressed)
+
+		if (pressed == KeyCodeMacBackspace || pressed == KeyCodeWinBackspace) && len(guess) != 0 {
+			guess = guess[:len(guess)-1]
+			_, _ = stat.WriteString(line, formatGuess(guess, false))
+
+			continue
+		}
+
+		if pressed == KeyCodeEnter && len(guess) == WordLength {
+			if reason := check(guess); reason != "" {
+				_, _ = stat.WriteString(line, formatGuess(guess, false)+" ("+reason+")")
+				continue
+			}
+
+			return guess, nil
+		}
+
+		if len(guess) < WordLength && isGuessLetter(pressed) {
+			guess += string(pressed)
+			_, _ = stat.WriteString(line, formatGuess(guess, false))
+		}
+	}
+}
+
+// mustBeWord is a readGuess check that only accepts valid words.
+func mustBeWord(guess string) string {
+	if !isWord(guess) {
+		return "must be a word"
+	}
+
+	return ""
+}
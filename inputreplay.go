@@ -0,0 +1,243 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-tty"
+)
+
+// KeyReader is where a game reads its keys from, the terminal or a replay.
+type KeyReader interface {
+	ReadKey() (rune, error)
+	Close() error
+}
+
+// terminalKeys reads keys from the terminal.
+type terminalKeys struct {
+	*tty.TTY
+}
+
+func (t terminalKeys) ReadKey() (rune, error) {
+	return t.ReadRune()
+}
+
+// keyWaiter is a KeyReader that can give up on a key that's slow to come.
+type keyWaiter interface {
+	ReadKeyWithin(timeout time.Duration) (r rune, ok bool, err error)
+}
+
+// readKeyWithin reads a key if one comes before the timeout. Readers that
+// can't give up wait as long as it takes.
+func readKeyWithin(keys KeyReader, timeout time.Duration) (rune, bool, error) {
+	if waiter, ok := keys.(keyWaiter); ok {
+		return waiter.ReadKeyWithin(timeout)
+	}
+
+	r, err := keys.ReadKey()
+
+	return r, err == nil, err
+}
+
+// ReadKeyWithin waits on the terminal with a read deadline, where the
+// terminal supports one. Whatever's already buffered is read right away.
+func (t terminalKeys) ReadKeyWithin(timeout time.Duration) (rune, bool, error) {
+	if !t.Buffered() && t.Input().SetReadDeadline(time.Now().Add(timeout)) == nil {
+		defer func() { _ = t.Input().SetReadDeadline(time.Time{}) }()
+	}
+
+	r, err := t.ReadRune()
+	if errors.Is(err, os.ErrDeadlineExceeded) {
+		return 0, false, nil
+	}
+
+	return r, err == nil, err
+}
+
+// gameSeed seeds the random answer, recorded with the input along with the
+// answer itself.
+var gameSeed int64
+
+// replayInput is the recording --replay-input plays back, if any. A replay is
+// never counted, it plays as if --no-stats was passed.
+var replayInput *replayReader
+
+// recordedKey is one key of a recording and how long after the one before it
+// was pressed.
+type recordedKey struct {
+	delay time.Duration
+	key   rune
+}
+
+// replayReader plays back keys recorded with --record-input, waiting between
+// them as long as the player did.
+//
+// A recording is a text file with a key per line: the milliseconds since the
+// key before it and the key, quoted the way Go quotes runes, e.g. 180 'C' or
+// 95 '\r'. "answer WORD" and "mode MODE" lines set the word played against and
+// whether it was a daily, whatever day it's played back on, and a "seed N"
+// line seeds the random answer of recordings without them. Blank lines and
+// lines that start with # are skipped, so recordings can be written or edited
+// by hand.
+type replayReader struct {
+	seed   int64
+	answer string
+	mode   string
+	keys   []recordedKey
+}
+
+func loadReplayInput(path string) (*replayReader, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	replay := &replayReader{}
+	scanner := bufio.NewScanner(f)
+
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.SplitN(line, " ", 2)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("%s:%d: expected a delay and a key", path, n)
+		}
+
+		switch fields[0] {
+		case "seed":
+			replay.seed, err = strconv.ParseInt(strings.TrimSpace(fields[1]), 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%s:%d: invalid seed %q", path, n, fields[1])
+			}
+
+			continue
+		case "answer":
+			replay.answer = strings.ToUpper(strings.TrimSpace(fields[1]))
+			if !isFiveLetters(replay.answer) {
+				return nil, fmt.Errorf("%s:%d: invalid answer %q", path, n, fields[1])
+			}
+
+			continue
+		case "mode":
+			replay.mode = strings.TrimSpace(fields[1])
+			if !knownGameMode(replay.mode) {
+				return nil, fmt.Errorf("%s:%d: unknown mode %q", path, n, fields[1])
+			}
+
+			continue
+		}
+
+		ms, err := strconv.Atoi(fields[0])
+		if err != nil || ms < 0 {
+			return nil, fmt.Errorf("%s:%d: invalid delay %q", path, n, fields[0])
+		}
+
+		key, err := strconv.Unquote(strings.TrimSpace(fields[1]))
+		if err != nil || len([]rune(key)) != 1 {
+			return nil, fmt.Errorf("%s:%d: invalid key %s, expected one quoted like 'C'", path, n, fields[1])
+		}
+
+		replay.keys = append(replay.keys, recordedKey{
+			delay: time.Duration(ms) * time.Millisecond,
+			key:   []rune(key)[0],
+		})
+	}
+
+	return replay, scanner.Err()
+}
+
+// ReadKey returns the next recorded key once its delay is up, or io.EOF when
+// the recording has run out.
+func (replay *replayReader) ReadKey() (rune, error) {
+	if len(replay.keys) == 0 {
+		return 0, io.EOF
+	}
+
+	next := replay.keys[0]
+	replay.keys = replay.keys[1:]
+
+	time.Sleep(next.delay)
+
+	return next.key, nil
+}
+
+// ReadKeyWithin plays back the next key if it was pressed within the timeout,
+// so a key recorded on its own is played back on its own.
+func (replay *replayReader) ReadKeyWithin(timeout time.Duration) (rune, bool, error) {
+	if len(replay.keys) > 0 && replay.keys[0].delay > timeout {
+		time.Sleep(timeout)
+		replay.keys[0].delay -= timeout
+
+		return 0, false, nil
+	}
+
+	r, err := replay.ReadKey()
+
+	return r, err == nil, err
+}
+
+func (replay *replayReader) Close() error {
+	return nil
+}
+
+// recordingReader writes every key read through it to a recording for
+// --replay-input.
+type recordingReader struct {
+	keys KeyReader
+	out  *os.File
+	last time.Time
+}
+
+func recordInput(keys KeyReader, path string) (*recordingReader, error) {
+	out, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Fprintf(out, "# wordle v%s input, play it back with --replay-input\n", version)
+	fmt.Fprintf(out, "seed %d\n", gameSeed)
+	fmt.Fprintf(out, "answer %s\n", word)
+	fmt.Fprintf(out, "mode %s\n", gameMode)
+
+	return &recordingReader{keys: keys, out: out, last: time.Now()}, nil
+}
+
+func (rec *recordingReader) ReadKey() (rune, error) {
+	r, err := rec.keys.ReadKey()
+	if err != nil {
+		return r, err
+	}
+
+	fmt.Fprintf(rec.out, "%d %s\n", time.Since(rec.last).Milliseconds(), strconv.QuoteRune(r))
+	rec.last = time.Now()
+
+	return r, nil
+}
+
+func (rec *recordingReader) ReadKeyWithin(timeout time.Duration) (rune, bool, error) {
+	r, ok, err := readKeyWithin(rec.keys, timeout)
+	if !ok {
+		return r, ok, err
+	}
+
+	fmt.Fprintf(rec.out, "%d %s\n", time.Since(rec.last).Milliseconds(), strconv.QuoteRune(r))
+	rec.last = time.Now()
+
+	return r, true, nil
+}
+
+func (rec *recordingReader) Close() error {
+	_ = rec.out.Close()
+
+	return rec.keys.Close()
+}
@@ -0,0 +1,250 @@
+package main
+
+import (
+	"bufio"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	IRCRetryDelay = 30 * time.Second
+	IRCLineLength = 400 // leaves room for the prefix servers add to the 512 byte limit
+	IRCLineDelay  = 500 * time.Millisecond
+)
+
+type IRCCommand struct {
+	Server  string `long:"server" required:"true" description:"IRC server to connect to" value-name:"HOST:PORT"`
+	Channel string `long:"channel" required:"true" description:"Channel to play in" value-name:"#CHANNEL"`
+	Nick    string `long:"nick" default:"wordlebot" description:"Nickname of the bot"`
+	TLS     bool   `long:"tls" description:"Connect with TLS"`
+}
+
+// ircClient is just enough of an IRC client to sit in one channel.
+type ircClient struct {
+	conn    net.Conn
+	lines   *bufio.Scanner
+	nick    string
+	channel string
+}
+
+// ircMessage is one line from the server, split into its parts.
+type ircMessage struct {
+	source  string
+	command string
+	params  []string
+}
+
+func (cmd *IRCCommand) Execute(_ []string) error {
+	gamestats := loadGameStats()
+	parseWordLists()
+
+	name := fmt.Sprintf("irc:%s/%s", cmd.Server, strings.ToLower(cmd.Channel))
+
+	fmt.Println("IRC bot running, press Ctrl+C to stop")
+
+	for {
+		err := cmd.serve(gamestats, name)
+		fmt.Fprintln(os.Stderr, err)
+
+		time.Sleep(IRCRetryDelay)
+	}
+}
+
+// serve plays in the channel until the connection drops.
+func (cmd *IRCCommand) serve(gamestats *GameStats, name string) error {
+	client, err := dialIRC(cmd.Server, cmd.TLS, cmd.Nick, "Wordle", cmd.Channel)
+	if err != nil {
+		return err
+	}
+	defer client.conn.Close()
+
+	for {
+		nick, text, err := client.next()
+		if err != nil {
+			return err
+		}
+
+		// anything else is just people talking
+		if !strings.HasPrefix(text, "!") {
+			continue
+		}
+
+		reply, err := gamestats.playChat(name, nick, text, "!")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "warning: chat stats: %v\n", err)
+		}
+
+		if reply == "" {
+			continue
+		}
+
+		err = client.say(reply)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// dialIRC connects and registers with a server. The channel is joined once
+// the server welcomes the client.
+func dialIRC(server string, useTLS bool, nick string, realName string, channel string) (*ircClient, error) {
+	var conn net.Conn
+
+	var err error
+
+	if useTLS {
+		conn, err = tls.Dial("tcp", server, nil)
+	} else {
+		conn, err = net.Dial("tcp", server)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	client := &ircClient{
+		conn:    conn,
+		lines:   bufio.NewScanner(conn),
+		nick:    nick,
+		channel: channel,
+	}
+
+	err = client.send("NICK " + nick)
+	if err == nil {
+		err = client.send(fmt.Sprintf("USER %s 0 * :%s", nick, realName))
+	}
+
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return client, nil
+}
+
+// next waits for the next message said in the channel and returns who said
+// it, taking care of the housekeeping the server asks for along the way.
+func (client *ircClient) next() (nick string, text string, err error) {
+	for client.lines.Scan() {
+		msg := parseIRC(client.lines.Text())
+
+		switch msg.command {
+		case "PING":
+			err = client.send("PONG :" + strings.Join(msg.params, " "))
+		case "001": // welcome
+			err = client.send("JOIN " + client.channel)
+		case "433": // nickname in use
+			client.nick += "_"
+			err = client.send("NICK " + client.nick)
+		case "PRIVMSG":
+			if len(msg.params) == 2 && strings.EqualFold(msg.params[0], client.channel) {
+				return msg.nick(), msg.params[1], nil
+			}
+		}
+
+		if err != nil {
+			return "", "", err
+		}
+	}
+
+	err = client.lines.Err()
+	if err == nil {
+		err = fmt.Errorf("irc: %s closed the connection", client.conn.RemoteAddr())
+	}
+
+	return "", "", err
+}
+
+// say sends text to the channel a line at a time, since a message can't hold
+// more than one. The lines are spaced out so servers don't kick the bot for
+// flooding.
+func (client *ircClient) say(text string) error {
+	for i, line := range strings.Split(text, "\n") {
+		if line == "" {
+			continue
+		}
+
+		if i > 0 {
+			time.Sleep(IRCLineDelay)
+		}
+
+		if len(line) > IRCLineLength {
+			line = line[:IRCLineLength]
+		}
+
+		err := client.send(fmt.Sprintf("PRIVMSG %s :%s", client.channel, line))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (client *ircClient) send(line string) error {
+	_, err := client.conn.Write([]byte(line + "\r\n"))
+	return err
+}
+
+// parseIRC splits a line like ":nick!user@host PRIVMSG #chan :hi there".
+// Message tags, which twitch always sends, are skipped.
+func parseIRC(line string) ircMessage {
+	msg := ircMessage{}
+
+	if strings.HasPrefix(line, "@") {
+		i := strings.IndexByte(line, ' ')
+		if i < 0 {
+			return msg
+		}
+
+		line = strings.TrimLeft(line[i:], " ")
+	}
+
+	if strings.HasPrefix(line, ":") {
+		i := strings.IndexByte(line, ' ')
+		if i < 0 {
+			return msg
+		}
+
+		msg.source = line[1:i]
+		line = strings.TrimLeft(line[i:], " ")
+	}
+
+	trailing := ""
+	hasTrailing := false
+
+	if i := strings.Index(line, " :"); i >= 0 {
+		trailing = line[i+2:]
+		hasTrailing = true
+		line = line[:i]
+	} else if strings.HasPrefix(line, ":") {
+		trailing = line[1:]
+		hasTrailing = true
+		line = ""
+	}
+
+	fields := strings.Fields(line)
+	if len(fields) > 0 {
+		msg.command = strings.ToUpper(fields[0])
+		msg.params = fields[1:]
+	}
+
+	if hasTrailing {
+		msg.params = append(msg.params, trailing)
+	}
+
+	return msg
+}
+
+// nick is the nickname part of the message's source.
+func (msg ircMessage) nick() string {
+	if i := strings.IndexByte(msg.source, '!'); i >= 0 {
+		return msg.source[:i]
+	}
+
+	return msg.source
+}
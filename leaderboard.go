@@ -0,0 +1,221 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+type LeaderboardCommand struct {
+	Local bool `long:"local" description:"Compare your stats with the stats files passed, e.g. other accounts' ~/.wordle"`
+
+	Args struct {
+		Files []string `positional-arg-name:"file" description:"Stats files to compare with your own"`
+	} `positional-args:"yes"`
+}
+
+// localProfile is one player's stats file on this machine.
+type localProfile struct {
+	name  string
+	stats *GameStats
+
+	// today is how today's daily went: guesses taken as N/6, X/6 for a loss,
+	// "playing" if it's unfinished, or empty if it hasn't been started
+	today   string
+	guesses int
+}
+
+func (cmd *LeaderboardCommand) Execute(_ []string) error {
+	if !cmd.Local {
+		return errors.New("there's no leaderboard server, pass --local to compare players on this machine")
+	}
+
+	parseWordLists()
+
+	day := int(time.Since(dailyEpoch).Hours() / 24)
+	profiles := []*localProfile{}
+
+	for name, path := range localStatsFiles(cmd.Args.Files) {
+		stats, err := readStatsFile(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "skipping %s: %v\n", path, err)
+			continue
+		}
+
+		profile := &localProfile{name: name, stats: stats}
+		profile.findToday(day)
+		profiles = append(profiles, profile)
+	}
+
+	if len(profiles) == 0 {
+		fmt.Println("No stats files to compare")
+		return nil
+	}
+
+	// solved first, by fewest guesses, then by streak
+	sort.Slice(profiles, func(i, j int) bool {
+		a, b := profiles[i], profiles[j]
+
+		switch {
+		case a.guesses != b.guesses:
+			return a.guesses < b.guesses
+		case a.stats.Streak != b.stats.Streak:
+			return a.stats.Streak > b.stats.Streak
+		}
+
+		return a.name < b.name
+	})
+
+	width := len("Player")
+	for _, profile := range profiles {
+		if displayWidth(profile.name) > width {
+			width = displayWidth(profile.name)
+		}
+	}
+
+	fmt.Printf("Today's Daily (day %d)\n\n", day)
+	fmt.Printf("%-*s  %-7s  %6s  %4s  %6s  %4s\n", width, "Player", "Today", "Streak", "Best", "Played", "Win%")
+
+	for _, profile := range profiles {
+		today := profile.today
+		if today == "" {
+			today = "-"
+		}
+
+		win := 0
+		if profile.stats.TotalGames > 0 {
+			win = profile.stats.winCount() * 100 / profile.stats.TotalGames
+		}
+
+		fmt.Printf("%s%s  %-7s  %6d  %4d  %6d  %4d\n", profile.name, strings.Repeat(" ", width-displayWidth(profile.name)), today,
+			profile.stats.Streak, profile.stats.BestStreak, profile.stats.TotalGames, win)
+	}
+
+	return nil
+}
+
+// findToday looks for today's daily in the profile's history, or its
+// unfinished progress.
+func (profile *localProfile) findToday(day int) {
+	profile.guesses = TotalGuesses + 2 // sorts after every finished daily
+
+	if progress := profile.stats.PendingDailies[""]; progress != nil && progress.Day == day {
+		profile.today = "playing"
+		profile.guesses = TotalGuesses + 1
+
+		return
+	}
+
+	answer := wordList[profile.stats.dailyIndex(day)]
+
+	for i := len(profile.stats.History) - 1; i >= 0; i-- {
+		game := profile.stats.History[i]
+		if game.Mode != GameModeDaily || game.Group != "" || game.Answer != answer {
+			continue
+		}
+
+		// a daily finished past midnight counts for the day before, but
+		// anything from before the day is an older daily with the same answer
+		if game.Date.Before(dailyEpoch.AddDate(0, 0, day-1)) {
+			break
+		}
+
+		if game.Won {
+			profile.today = fmt.Sprintf("%d/%d", len(game.Guesses), TotalGuesses)
+			profile.guesses = len(game.Guesses)
+		} else {
+			profile.today = fmt.Sprintf("X/%d", TotalGuesses)
+			profile.guesses = TotalGuesses + 1
+		}
+
+		return
+	}
+}
+
+// winCount adds up the wins across the guess distribution.
+func (gs *GameStats) winCount() int {
+	wins := 0
+	for _, count := range gs.Wins {
+		wins += count
+	}
+
+	return wins
+}
+
+// localStatsFiles names the stats files to compare: your own and the ones
+// passed in, keyed by who they belong to. Other home directories are never
+// searched, only files someone chose to share are read.
+func localStatsFiles(extra []string) map[string]string {
+	files := map[string]string{}
+
+	if own, _, err := statsPath(); err == nil {
+		if _, err := os.Stat(own); err == nil {
+			files[profileName(own)] = own
+		}
+	}
+
+	for _, path := range extra {
+		if !containsPath(files, path) {
+			files[profileName(path)] = path
+		}
+	}
+
+	return files
+}
+
+// profileName names a stats file after the file, e.g. "alex" for alex.json,
+// or after the directory it's in for a .wordle or stats.json, e.g. "alex" for
+// /home/alex/.wordle.
+func profileName(path string) string {
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	if name == "" || name == "stats" {
+		name = filepath.Base(filepath.Dir(path))
+	}
+
+	return name
+}
+
+// containsPath checks whether a file has already been found.
+func containsPath(files map[string]string, path string) bool {
+	abs, _ := filepath.Abs(path)
+
+	for _, found := range files {
+		if other, _ := filepath.Abs(found); other == abs {
+			return true
+		}
+	}
+
+	return false
+}
+
+// readStatsFile reads someone else's stats without changing the file, unlike
+// loadGameStats which clears out a file it can't read.
+func readStatsFile(path string) (*GameStats, error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if isEncryptedStats(raw) {
+		return nil, errors.New("the stats are encrypted")
+	}
+
+	gamestats := &GameStats{}
+
+	err = json.NewDecoder(bytes.NewReader(raw)).Decode(gamestats)
+	if err != nil {
+		return nil, err
+	}
+
+	gamestats.migrateHistory()
+	gamestats.migrateDailies()
+
+	return gamestats, nil
+}
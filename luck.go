@@ -0,0 +1,75 @@
+package main
+
+// gameLuck scores from 0 to 100 how lucky the guesses were. For each guess
+// that had more than one possible answer to choose from, its luck is the
+// chance that the pattern it got left at least as many answers as it did,
+// ties counting half. 50 is as lucky as expected, a guess that happens to
+// win early scores close to 100. It returns -1 when there's nothing to judge.
+func gameLuck(guesses []string, answer string) int {
+	candidates := newCandidateSet(wordList)
+	counts := make([]int, PatternCount)
+
+	total := 0.0
+	judged := 0
+
+	for _, guess := range guesses {
+		if len(candidates.words) <= 1 {
+			break
+		}
+
+		pattern := feedback(guess, answer)
+
+		candidates.countPatterns(guess, counts)
+		actual := counts[pattern]
+
+		worse := 0
+		same := 0
+
+		for _, count := range counts {
+			switch {
+			case count > actual:
+				worse += count
+			case count == actual:
+				same += count
+			}
+		}
+
+		total += (float64(worse) + float64(same)/2) / float64(len(candidates.words))
+		judged++
+
+		candidates = candidates.filter(guess, pattern)
+	}
+
+	if judged == 0 {
+		return -1
+	}
+
+	return int(total * 100 / float64(judged))
+}
+
+// averageLuck averages the luck of every game it was recorded for, it returns
+// -1 when there's nothing to average.
+func (gs *GameStats) averageLuck() int {
+	total := 0
+	games := 0
+
+	for _, game := range gs.History {
+		if game.Luck == nil {
+			continue
+		}
+
+		total += *game.Luck
+		games++
+	}
+
+	for _, summary := range gs.Compacted {
+		total += summary.Luck
+		games += summary.LuckGames
+	}
+
+	if games == 0 {
+		return -1
+	}
+
+	return total / games
+}
@@ -1,27 +1,27 @@
 package main
 
 import (
-	"bufio"
 	"bytes"
 	_ "embed"
 	"encoding/json"
 	"fmt"
+	"hash/fnv"
+	"io"
 	"io/ioutil"
 	"math"
 	"math/rand"
 	"os"
 	"os/signal"
-	"path"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
-	"unicode"
 
-	"github.com/coreyog/statux"
 	"github.com/fatih/color"
 	"github.com/jessevdk/go-flags"
 	"github.com/mattn/go-tty"
+	"golang.org/x/term"
 )
 
 const (
@@ -31,8 +31,16 @@ const (
 
 	KeyCodeWinBackspace = 8
 	KeyCodeEnter        = 13
+	KeyCodeEscape       = 27
 	KeyCodeMacBackspace = 127
 
+	ArrowUp   = 'A'
+	ArrowDown = 'B'
+
+	// EscapeTimeout is how long to wait after the escape key for the rest of
+	// an escape sequence before taking it as pressed on its own.
+	EscapeTimeout = 50 * time.Millisecond
+
 	EmojiNotInWord = '⬛'
 	EmojiSomewhere = '🟨'
 	EmojiLocated   = '🟩'
@@ -48,6 +56,16 @@ const (
 	KeyHintLocated
 )
 
+const (
+	LayoutTop    = "top"
+	LayoutBottom = "bottom"
+
+	KeyboardRows = 3
+	PanelLines   = 1
+
+	KeyPanelToggle = '?'
+)
+
 var hintColorFns = map[KeyHint]ColorFunc{
 	KeyHintUnknown:   fmt.Sprintf,
 	KeyHintNotInWord: color.RedString,
@@ -67,37 +85,171 @@ var rawBadWordList string
 var version string
 
 var wordList []string
-var allowedWords []string
+var wordListsOnce sync.Once
 var word string
 var discovered []bool = make([]bool, WordLength)
 
 var keyboard map[rune]KeyHint
+
+// changedKeys are the keys whose hint changed since the keyboard was last
+// drawn, shown in inverse video for a moment so new information stands out.
+// flashedKeys is whether the last drawing highlighted any.
+var changedKeys = map[rune]bool{}
+var flashedKeys bool
 var emojiStack []string = []string{}
+var guessHistory []string = []string{}
+var guessTimes []time.Duration = []time.Duration{}
+var showPanel bool
+var guessBudget = TotalGuesses
+
+// boardRows is how many guess rows the board being played has, which the
+// screen layout is worked out from.
+var boardRows = TotalGuesses
 var dayOffset int
 
+// dailyEpoch is the day of the first daily puzzle.
+var dailyEpoch = time.Date(2021, time.June, 19, 0, 0, 0, 0, time.UTC)
+
 type GameStats struct {
-	TotalGames               int        `json:"total_games"`
-	TotalHardGames           int        `json:"total_hard_games"`
-	Wins                     []int      `json:"wins"`
-	HardWins                 []int      `json:"hard_wins"`
-	Streak                   int        `json:"streak"`
-	BestStreak               int        `json:"best_streak"`
-	LastDaily                *time.Time `json:"last_daily"`
-	ExperimentalEmojiSupport bool       `json:"experimental_emoji_support"`
-	DefaultToHardMode        bool       `json:"default_to_hard_mode"`
+	TotalGames               int                   `json:"total_games"`
+	TotalHardGames           int                   `json:"total_hard_games"`
+	Wins                     []int                 `json:"wins"`
+	HardWins                 []int                 `json:"hard_wins"`
+	Streak                   int                   `json:"streak"`
+	BestStreak               int                   `json:"best_streak"`
+	LastDaily                *time.Time            `json:"last_daily"`
+	LastDailyDay             int                   `json:"last_daily_day"`
+	GroupDailies             map[string]*time.Time `json:"group_dailies"`
+	GroupDailyDays           map[string]int        `json:"group_daily_days"`
+	DailyInProgress          *DailyProgress        `json:"daily_in_progress,omitempty"` // before groups had their own
+	PendingDailies           PendingDailies        `json:"pending_dailies,omitempty"`
+	History                  []GameRecord          `json:"history"`
+	Compacted                []HistorySummary      `json:"compacted_history,omitempty"`
+	ExperimentalEmojiSupport bool                  `json:"experimental_emoji_support"`
+	DefaultToHardMode        bool                  `json:"default_to_hard_mode"`
+	OpeningWord              string                `json:"opening_word"`
+	InterruptPolicy          string                `json:"interrupt_policy"`
+	ShareTiming              bool                  `json:"share_timing"`
+	PuzzleNumberOffset       int                   `json:"puzzle_number_offset"`
+	BannedOpeners            []string              `json:"banned_openers"`
+	BanLastAnswer            bool                  `json:"ban_last_answer"`
+	Blitz                    BlitzStats            `json:"blitz"`
+	Finale                   FinaleStats           `json:"finale"`
+	Double                   DoubleStats           `json:"double"`
+	Relay                    map[string]RelayStats `json:"relay,omitempty"`
+	Phrase                   PhraseStats           `json:"phrase"`
+	Emoji                    string                `json:"emoji"`
+	ShareFormat              string                `json:"share_format"`
+	Assisted                 AssistedStats         `json:"assisted"`
+	Adaptive                 AdaptiveStats         `json:"adaptive"`
+	OpenerGames              int                   `json:"opener_games"`
+	HistoryVersion           int                   `json:"history_version"`
+	SignShares               bool                  `json:"sign_shares"`
+	TranscriptChecksum       bool                  `json:"transcript_checksum"`
+	ShareLevel               string                `json:"share_level"`
+	SoundCues                bool                  `json:"sound_cues"`
+	Theme                    string                `json:"theme"`
+	ShareKeys                bool                  `json:"share_keys"`
+	Chats                    map[string]*ChatStats `json:"chats,omitempty"`
+	MQTTBroker               string                `json:"mqtt_broker"`
+	MQTTTopic                string                `json:"mqtt_topic"`
+	MQTTUsername             string                `json:"mqtt_username"`
+	MQTTQueue                []QueuedMQTTMessage   `json:"mqtt_queue,omitempty"`
+	DailyRotation            string                `json:"daily_rotation"`
+	FunFacts                 bool                  `json:"fun_facts"`
+	Achievements             []Achievement         `json:"achievements,omitempty"`
+	Plan                     *WordPlan             `json:"plan,omitempty"`
+
+	// encrypted is whether the stats file is encrypted, it stays that way
+	// once it is
+	encrypted bool
 }
 
 type Arguments struct {
-	HardMode     bool `short:"H" long:"hard" description:"Play in hard mode"`
-	PrintStats   bool `short:"s" long:"stats" description:"Print stats"`
-	PrintVersion bool `short:"v" long:"version" description:"Prints the version"`
+	HardMode        bool   `short:"H" long:"hard" description:"Play in hard mode"`
+	PrintStats      bool   `short:"s" long:"stats" description:"Print stats"`
+	PrintVersion    bool   `short:"v" long:"version" description:"Prints the version"`
+	Explain         bool   `long:"explain" description:"Explain the colors of repeated letters after each guess"`
+	Opener          string `long:"opener" description:"Pre-fill the first guess with this word" value-name:"WORD"`
+	RandomOpener    bool   `long:"random-opener" description:"Open with a random strong first guess, submitted for you"`
+	Group           string `long:"group" description:"Play a private daily shared by everyone using the same group name" value-name:"NAME"`
+	Layout          string `long:"layout" choice:"top" choice:"bottom" default:"top" description:"Where the guesses go relative to the keyboard"`
+	Panel           bool   `long:"panel" description:"Start with the hints panel open, it can be toggled with ?"`
+	Number          int    `long:"number" description:"Puzzle number to show when sharing" value-name:"N"`
+	Typing          bool   `long:"typing" description:"Also score how fast and accurately guesses are typed"`
+	Trainer         bool   `long:"trainer" description:"After each guess, show the most common letters among the words still possible"`
+	Adaptive        bool   `long:"adaptive" description:"Adjust how common the word's letters are and the guesses allowed to your recent win rate"`
+	Hints           string `long:"hints" choice:"category" description:"Offer a hint after the third missed guess"`
+	PreciseKeyboard bool   `long:"precise-keyboard" description:"Mark green keys that are also known to be somewhere else in the word"`
+	Novice          bool   `long:"novice" description:"Warn before submitting a guess that reuses letters already ruled out"`
+	NoRepeats       bool   `long:"no-repeats" description:"Training wheels: the first two guesses can't repeat a letter, within or between them"`
+	Suggest         string `long:"suggest" choice:"personal" choice:"full" description:"With --trainer, also suggest a next guess from words you've guessed before or from every valid word"`
+	Strategy        string `long:"strategy" default:"expected" description:"How --suggest picks: expected, frequency, entropy, minimax, or exec:COMMAND to ask another program" value-name:"NAME"`
+	NoStats         bool   `long:"no-stats" description:"Never read or write the stats file, for privacy or running from read-only media"`
+	EncryptStats    bool   `long:"encrypt-stats" description:"Encrypt the stats file with a passphrase, asked for or taken from WORDLE_STATS_PASSPHRASE"`
+	ShareLevel      string `long:"share-level" choice:"full" choice:"minimal" choice:"anonymous" description:"How much the share block gives away, overrides the share_level config"`
+	Yesterday       bool   `long:"yesterday" description:"Show yesterday's daily answer before starting, it's hidden by default to avoid spoilers"`
+	Facts           bool   `long:"facts" description:"After a daily, show a fun fact or etymology for the word when there is one"`
+	LetterRarity    bool   `long:"letter-rarity" description:"After each game, show how unusual the answer's letters are next to the other answers, a rough stand-in for how rare the word is"`
+	ShareFormat     string `long:"share-format" choice:"emoji" choice:"text" description:"Share with emoji squares or plain #, +, and . characters, overrides the share_format config"`
+	Emoji           string `long:"emoji" choice:"auto" choice:"on" choice:"off" description:"Whether to print the emoji share block, guessed from the terminal by default"`
+	Braille         bool   `long:"braille" description:"Experimental: also show each scored guess as 8 dot braille, for braille displays"`
+	Sound           bool   `long:"sound" description:"Ring the terminal bell for keys, invalid words, revealed rows, and the result"`
+	Mute            bool   `long:"mute" description:"Turn sound cues off, even if the config turns them on"`
+	Theme           string `long:"theme" choice:"auto" choice:"dark" choice:"light" description:"Colors for a dark or light terminal background, detected by default"`
+	ComputeFeedback bool   `long:"compute-feedback" description:"Score patterns on the fly instead of building or reading the evaluation cache"`
+	FPS             int    `long:"fps" default:"30" description:"Most times a second to redraw the board, lower it for slow connections, 0 for no limit" value-name:"N"`
+	Multiplexer     string `long:"multiplexer" choice:"auto" choice:"tmux" choice:"screen" choice:"none" default:"auto" description:"Terminal multiplexer the game runs in, detected by default"`
+	ReplayInput     string `long:"replay-input" description:"Play keys recorded with --record-input instead of reading the keyboard, for testing and bug reports, without saving stats" value-name:"FILE"`
+	Slow            int    `long:"slow" description:"Slow mode: don't accept a guess until it's been thought about this many seconds" value-name:"SECONDS"`
+	RecordInput     string `long:"record-input" description:"Record every key pressed, with timing, to play back with --replay-input" value-name:"FILE"`
+	InputLayout     string `long:"input-layout" choice:"qwerty" choice:"azerty" choice:"qwertz" choice:"dvorak" choice:"cyrillic" default:"qwerty" description:"Layout of the physical keyboard, when it differs from what the system is set to"`
+	StrictLists     bool   `long:"strict-lists" description:"Also warn about word list problems the game can work around, like overlaps and ordering, as check-lists does"`
+	TwitchChannel   string `long:"twitch-channel" description:"Let a Twitch channel's chat vote on each guess, for streaming" value-name:"NAME"`
+	VoteSeconds     int    `long:"vote-seconds" default:"30" description:"With --twitch-channel, how long chat has to vote on each guess" value-name:"N"`
+	SoftKeyboard    bool   `long:"soft-keyboard" description:"Type lines of key numbers from the on-screen keyboard, for terminals where single keys can't be read"`
+	Mouse           bool   `long:"mouse" description:"Click the on-screen keyboard to type, in terminals that report mouse clicks"`
+	Preplan         int    `long:"preplan" description:"Pick the next N random answers ahead of time and write their hashes to a file, to prove they weren't rerolled" value-name:"N"`
+	PlanFile        string `long:"plan-file" default:"wordle-plan.txt" description:"With --preplan, where to write the plan" value-name:"FILE"`
+
+	Stats            StatsCommand            `command:"stats" description:"Print stats and breakdowns of past games"`
+	Replay           ReplayCommand           `command:"replay" description:"Step through a past game one guess at a time"`
+	View             ViewCommand             `command:"view" description:"Watch a game shared as a replay code without seeing the answer first"`
+	ImportShare      ImportShareCommand      `command:"import-share" description:"Record share blocks pasted on stdin as played games"`
+	Blitz            BlitzCommand            `command:"blitz" description:"Solve five random words against one five minute clock"`
+	Finale           FinaleCommand           `command:"finale" description:"One guess at a word with some letters given away"`
+	Double           DoubleCommand           `command:"double" description:"Find two hidden words on one board in eight guesses"`
+	Phrase           PhraseCommand           `command:"phrase" description:"Experimental: solve a two word phrase on two boards, guessing at each in turn"`
+	Drill            DrillCommand            `command:"drill" description:"Practice on answers that differ by a letter, like the _IGHT words"`
+	Relay            RelayCommand            `command:"relay" description:"Two or three players take turns guessing on one board as a team"`
+	Verify           VerifyCommand           `command:"verify" description:"Check the signature on a share block"`
+	ShareKey         ShareKeyCommand         `command:"share-key" description:"Print the public key others need to check your signed share blocks"`
+	VerifyTranscript VerifyTranscriptCommand `command:"verify-transcript" description:"Check a game transcript against its checksum"`
+	VerifyPlan       VerifyPlanCommand       `command:"verify-plan" description:"Check the words played from a --preplan file against their hashes"`
+	Print            PrintCommand            `command:"print" description:"Print blank boards for upcoming dailies with an answer key"`
+	Audit            AuditCommand            `command:"audit" description:"Show where the daily is in the answer rotation"`
+	Score            ScoreCommand            `command:"score" description:"Show how a guess would be scored against an answer"`
+	TelegramBot      TelegramCommand         `command:"telegram-bot" description:"Run the daily as a Telegram bot, each chat playing together"`
+	IRCBot           IRCCommand              `command:"irc-bot" description:"Run the daily as an IRC bot, the channel playing together"`
+	MatrixBot        MatrixCommand           `command:"matrix-bot" description:"Run the daily as a Matrix bot, the room playing together"`
+	Digest           DigestCommand           `command:"digest" description:"Summarize the last week of games, or email the summary, for cron"`
+	Backup           BackupCommand           `command:"backup" description:"Move stats, history, and config to another machine with an archive"`
+	CheckLists       CheckListsCommand       `command:"check-lists" description:"Check a pair of word lists for problems, e.g. before using a custom pack"`
+	Analyze          AnalyzeCommand          `command:"analyze" description:"Compare each guess of a past game to the best by worst case and by information"`
+	Leaderboard      LeaderboardCommand      `command:"leaderboard" description:"Compare today's daily and streaks with everyone who plays on this machine"`
+	History          HistoryCommand          `command:"history" description:"Manage the game by game history kept in the stats file"`
+	DecryptStats     DecryptStatsCommand     `command:"decrypt-stats" description:"Turn an encrypted stats file back into a plain one"`
+	YesterdayAnswer  YesterdayCommand        `command:"yesterday" description:"Show yesterday's daily answer and how you did"`
 }
 
 var args Arguments
 
 func main() {
 	// parse flags
-	_, err := flags.Parse(&args)
+	parser := flags.NewParser(&args, flags.Default)
+	parser.SubcommandsOptional = true
+
+	_, err := parser.Parse()
 	if err != nil {
 		if flags.WroteHelp(err) {
 			printUsage()
@@ -107,12 +259,33 @@ func main() {
 		os.Exit(1)
 	}
 
+	if parser.Active != nil {
+		// a subcommand already ran
+		return
+	}
+
 	if args.PrintVersion {
 		fmt.Printf("v%s\n", version)
 		os.Exit(0)
 	}
 
+	if args.NoStats && args.EncryptStats {
+		fmt.Fprintln(os.Stderr, "--encrypt-stats has nothing to encrypt with --no-stats")
+		os.Exit(1)
+	}
+
+	if args.Mouse && args.SoftKeyboard {
+		fmt.Fprintln(os.Stderr, "--mouse needs single keys to be read, it can't be used with --soft-keyboard")
+		os.Exit(1)
+	}
+
+	// a replay is only watched, it mustn't count as playing
+	if args.ReplayInput != "" {
+		args.NoStats = true
+	}
+
 	gamestats := loadGameStats()
+	gamestats.applyTheme()
 
 	if gamestats.DefaultToHardMode {
 		args.HardMode = true
@@ -126,39 +299,249 @@ func main() {
 	// parse word list deterministically even if compiled on windows
 	parseWordLists()
 
-	shouldPlayDaily := gamestats.LastDaily == nil || time.Since(*gamestats.LastDaily) > 24*time.Hour
+	if args.Yesterday {
+		printYesterday(gamestats)
+		fmt.Println()
+	}
+
+	if args.ReplayInput != "" {
+		replayInput, err = loadReplayInput(args.ReplayInput)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+
+	if args.TwitchChannel != "" {
+		err = playTwitch(args.TwitchChannel, time.Duration(args.VoteSeconds)*time.Second)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+
+		return
+	}
 
 	// calculate day offset
-	dayOffset = int(time.Since(time.Date(2021, time.June, 19, 0, 0, 0, 0, time.UTC)).Hours() / 24)
+	dayOffset = int(time.Since(dailyEpoch).Hours() / 24)
+
+	lastDailyDay := gamestats.LastDailyDay
+	if args.Group != "" {
+		lastDailyDay = gamestats.GroupDailyDays[args.Group]
+	}
+
+	shouldPlayDaily := lastDailyDay < dayOffset
+
+	// an unfinished daily has to be finished in the mode it was started in
+	progress := gamestats.resumableDaily()
+	if progress != nil {
+		if progress.HardMode != args.HardMode {
+			if progress.HardMode {
+				fmt.Println("Today's daily was started in hard mode, pass -H to finish it")
+			} else {
+				fmt.Println("Today's daily was started in normal mode, it can't be finished in hard mode")
+			}
+
+			os.Exit(1)
+		}
+
+		shouldPlayDaily = true
+	}
+
+	interruptPolicy, ok := gamestats.interruptPolicy()
+	if !ok {
+		fmt.Fprintf(os.Stderr, "warning: unknown interrupt_policy %q in the config, expected %s, %s, or %s, using %s\n",
+			gamestats.InterruptPolicy, InterruptResume, InterruptForfeit, InterruptGrace, InterruptResume)
+	}
+
+	// a replay has to play against the word it was recorded with
+	gameSeed = time.Now().UnixNano()
+	if replayInput != nil && replayInput.seed != 0 {
+		gameSeed = replayInput.seed
+	}
+
+	if replayInput != nil && replayInput.mode != "" {
+		shouldPlayDaily = replayInput.mode == GameModeDaily || replayInput.mode == GameModeGroup
+	}
+
+	if args.Preplan != 0 {
+		switch {
+		case args.Preplan < 0:
+			fmt.Fprintln(os.Stderr, "--preplan needs at least 1 word")
+			os.Exit(1)
+		case shouldPlayDaily:
+			fmt.Fprintln(os.Stderr, "--preplan is for practice games, play today's daily first")
+			os.Exit(1)
+		case gamestats.Plan.remaining() > 0:
+			fmt.Fprintf(os.Stderr, "the plan in %s still has %d words to play\n", gamestats.Plan.File, gamestats.Plan.remaining())
+			os.Exit(1)
+		}
+
+		gamestats.Plan, err = newWordPlan(args.Preplan, args.PlanFile)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+
+		_ = gamestats.save()
+
+		fmt.Printf("  Planned the next %d words, their hashes are in %s\n", args.Preplan, gamestats.Plan.File)
+	}
+
+	planIndex := -1 // which planned word is being played
 
 	// pick word
 	if shouldPlayDaily {
 		fmt.Println("   Daily Puzzle!")
 
-		index := dayOffset % len(wordList)
+		gameMode = GameModeDaily
+		if args.Group != "" {
+			gameMode = GameModeGroup
+		}
+
+		if warning := rotationWarning(len(wordList), gamestats.DailyRotation); warning != "" && gamestats.DailyRotation == "" && args.Group == "" && progress == nil {
+			fmt.Fprintf(os.Stderr, "warning: %s\n", warning)
+		}
+
+		index := gamestats.dailyIndex(dayOffset)
+		if args.Group != "" {
+			index = groupWordIndex(args.Group, dayOffset)
+		}
+
 		word = wordList[index]
+		if progress != nil {
+			word = progress.Answer
+		}
+
+		// the day decides whether the daily has been played, the time is
+		// kept for reference with its zone spelled out
+		now := time.Now()
 
-		now := time.Now().UTC()
-		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
-		gamestats.LastDaily = &today
+		if args.Group != "" {
+			if gamestats.GroupDailies == nil {
+				gamestats.GroupDailies = map[string]*time.Time{}
+				gamestats.GroupDailyDays = map[string]int{}
+			}
+
+			gamestats.GroupDailies[args.Group] = &now
+			gamestats.GroupDailyDays[args.Group] = dayOffset
+		} else {
+			gamestats.LastDaily = &now
+			gamestats.LastDailyDay = dayOffset
+		}
 	} else {
-		rand.Seed(time.Now().UnixNano())
+		rand.Seed(gameSeed)
 		word = wordList[rand.Intn(len(wordList))]
+
+		if args.Adaptive {
+			var tier int
+			tier, guessBudget = gamestats.adaptiveSettings()
+			gameMode = GameModeAdaptive
+
+			pool := difficultyTiers(wordList)[tier]
+			word = pool[rand.Intn(len(pool))]
+
+			fmt.Printf("  Adaptive: %s, %d guesses\n", tierNames[tier], guessBudget)
+		}
+
+		if plan := gamestats.Plan; plan.remaining() > 0 {
+			planIndex = plan.Next
+			word = plan.Words[planIndex]
+
+			fmt.Printf("  Planned word %d of %d\n", planIndex+1, len(plan.Words))
+		}
+	}
+
+	if replayInput != nil && replayInput.answer != "" {
+		word = replayInput.answer
+	}
+
+	if replayInput != nil && replayInput.mode != "" {
+		gameMode = replayInput.mode
 	}
 
-	sort.Strings(wordList)
 	// fmt.Println(word) // debugging
 
 	initKeyboard()
 
+	soundEnabled = (gamestats.SoundCues || args.Sound) && !args.Mute
+
+	if args.Group != "" {
+		fmt.Printf("   Group: %s\n", args.Group)
+	}
+
 	if args.HardMode {
 		fmt.Println("     Hard Mode")
 	}
 
+	// the trainer would be cheating in a game that's compared with others
+	practice := !shouldPlayDaily && args.Group == ""
+	trainer := args.Trainer && practice
+	if args.Trainer && !trainer {
+		fmt.Println("  (trainer is off for dailies)")
+	}
+
+	var suggestions []string
+
+	var strategy Strategy
+
+	if trainer && args.Suggest != "" {
+		suggestions = gamestats.suggestionPool(args.Suggest)
+
+		strategy, err = newStrategy(args.Strategy)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+
+	// only suggestions score whole pools, the rest of the trainer is quick
+	if trainer && args.Suggest != "" {
+		var err error
+
+		evalCache, err = loadEvalCache()
+		if err != nil {
+			fmt.Printf("  (feedback not cached: %v)\n", err)
+		}
+	}
+
+	// remember the terminal's settings before raw input takes over
+	cooked, _ := term.GetState(int(os.Stdin.Fd()))
+
 	// prepare key listener
-	ty, err := tty.Open()
-	if err != nil {
-		panic(err)
+	var terminal *tty.TTY
+	var ty KeyReader
+
+	var soft *softKeyboard
+
+	if replayInput != nil {
+		ty = replayInput
+	} else if args.SoftKeyboard {
+		soft = newSoftKeyboard(promptLine())
+		ty = soft
+	} else {
+		terminal, err = tty.Open()
+		if err != nil {
+			panic(err)
+		}
+
+		ty = terminalKeys{terminal}
+	}
+
+	if args.RecordInput != "" {
+		ty, err = recordInput(ty, args.RecordInput)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+
+	// keys that come in while waiting on the terminal's reply to where the
+	// board is are handed back to be read again
+	var mouseKeys *pushbackKeys
+	if args.Mouse {
+		mouseKeys = &pushbackKeys{KeyReader: ty}
+		ty = mouseKeys
 	}
 
 	tyOpen := true
@@ -169,20 +552,63 @@ func main() {
 	}()
 
 	// prepare output
-	stat, err := statux.New(TotalGuesses + 4) // +1 for "status" line, +3 for keyboard
+	lines := TotalGuesses + 1 + KeyboardRows + PanelLines // +1 for "status" line
+	if soft != nil {
+		lines++
+	}
+
+	anchorBoard()
+
+	stat, err := newScreen(lines)
 	if err != nil {
 		panic(err)
 	}
 
+	if soft != nil {
+		soft.stat = stat
+	}
+
+	enableMouse()
+
+	if args.Mouse {
+		locateBoard(mouseKeys, stat)
+	}
+
+	if terminal != nil {
+		watchSuspend(terminal, stat, cooked)
+	}
+
 	defer func() {
 		if !stat.IsFinished() {
+			disableMouse()
 			stat.Finish()
 		}
 	}()
 
 	// setup game state
-	guess := ""
+	opener := ""
+	if progress == nil {
+		opener = openingWord(gamestats)
+	}
+
+	// keys acted on before any are read, like the Enter that submits a
+	// random opener
+	var queued []rune
+
+	if args.RandomOpener && progress == nil {
+		if forced := randomOpener(gamestats); forced != "" {
+			opener = forced
+			queued = append(queued, KeyCodeEnter)
+		}
+	}
+
+	guess := opener
 	win := false
+	openerUsed := false // whether the first guess counted as the opener
+
+	// index into submitted guesses, recallable with the arrow keys
+	recall := 0
+	confirmed := "" // guess the novice guard already warned about
 
 	// listen for interrupts to cleanup terminal trickery
 	c := make(chan os.Signal, 1)
@@ -196,13 +622,61 @@ func main() {
 			tyOpen = false
 		}
 
+		disableMouse()
 		stat.Finish()
 
+		if strategy != nil {
+			_ = strategy.Close()
+		}
+
 		if !win && currentGuess != 0 {
-			gamestats.Streak = 0
-			_ = gamestats.save()
+			if planIndex != -1 {
+				gamestats.Plan.reveal(planIndex)
+			}
+
+			switch {
+			case shouldPlayDaily && interruptPolicy != InterruptForfeit:
+				// progress is saved after every guess
+				fmt.Println("\nToday's daily was saved, run wordle again to finish it")
+			case !shouldPlayDaily && interruptPolicy == InterruptGrace:
+				// let it go like it never happened
+				if args.HardMode {
+					gamestats.TotalHardGames--
+				} else {
+					gamestats.TotalGames--
+				}
 
-			fmt.Printf("\nThe word was %s\n", word)
+				if openerUsed {
+					gamestats.OpenerGames--
+				}
+
+				_ = gamestats.save()
+
+				fmt.Printf("\nThe word was %s\n", word)
+			case assisted:
+				gamestats.countAssisted(args.HardMode, false, 0)
+				gamestats.finishDaily()
+				gamestats.recordGame(false)
+				_ = gamestats.save()
+				gamestats.publishGame()
+
+				fmt.Printf("\nThe word was %s\n", word)
+			case gameMode == GameModeAdaptive:
+				gamestats.countAdaptive(args.HardMode, false, 0)
+				gamestats.recordGame(false)
+				_ = gamestats.save()
+				gamestats.publishGame()
+
+				fmt.Printf("\nThe word was %s\n", word)
+			default:
+				gamestats.Streak = 0
+				gamestats.finishDaily()
+				gamestats.recordGame(false)
+				_ = gamestats.save()
+				gamestats.publishGame()
+
+				fmt.Printf("\nThe word was %s\n", word)
+			}
 		}
 
 		os.Exit(0)
@@ -211,52 +685,253 @@ func main() {
 	// print the initial game state
 	for i := 0; i < TotalGuesses; i++ {
 		if i == 0 {
-			_, _ = stat.WriteString(i, "     █ _ _ _ _")
+			_, _ = stat.WriteString(guessLine(i), formatGuess(guess, false))
 		} else {
-			_, _ = stat.WriteString(i, "     _ _ _ _ _")
+			_, _ = stat.WriteString(guessLine(i), blankRow(i))
 		}
 	}
 
+	showPanel = args.Panel
+
 	printKeyboard(stat)
+	printPanel(stat)
+
+	// pick up where an unfinished daily left off
+	if progress != nil {
+		assisted = progress.Assisted
+
+		for _, previous := range progress.Guesses {
+			_, _ = stat.WriteString(guessLine(currentGuess), formatGuess(previous, true))
+			guessHistory = append(guessHistory, previous)
+			guessTimes = append(guessTimes, 0) // not timed
+			typing.Submit()
+			currentGuess++
+			advanceGuessRow(stat)
+		}
+
+		recall = len(guessHistory)
+		changedKeys = map[rune]bool{} // nothing new, it's all from before
+
+		_, _ = stat.WriteString(guessLine(currentGuess), formatGuess(guess, false))
+		printKeyboard(stat)
+		printPanel(stat)
+	}
 
 	// start the game
+	rowStarted := time.Now()
+
+	var slow *thinkTimer
+	if args.Slow > 0 {
+		slow = startThinkTimer(stat, time.Duration(args.Slow)*time.Second)
+	}
+
 	for { // main loop
 		// read user input
-		pressed, err := ty.ReadRune()
-		if err != nil {
-			panic(err)
+		var pressed rune
+
+		// everything the last key changed goes out in one frame
+		stat.Flush()
+
+		if len(queued) > 0 {
+			pressed, queued = queued[0], queued[1:]
+		} else {
+			pressed, err = ty.ReadKey()
+			if err == io.EOF && replayInput != nil {
+				stat.Finish()
+
+				if strategy != nil {
+					_ = strategy.Close()
+				}
+
+				fmt.Println("The replayed input ran out before the game was over")
+				os.Exit(1)
+			}
+
+			if err != nil {
+				panic(err)
+			}
+		}
+
+		stat.Begin()
+
+		// the keys that just changed are only highlighted until the next key
+		if flashedKeys {
+			printKeyboard(stat)
+		}
+
+		pressed = translateInput(pressed)
+
+		// _, _ = stat.WriteString(statusLine(), fmt.Sprintf("%d", int(pressed))) // debugging tty
+
+		// input was an arrow key, recall a previous guess
+		if pressed == KeyCodeEscape {
+			sequence, other := readEscapeSequence(ty)
+			if other != 0 {
+				queued = append(queued, other)
+			}
+
+			switch sequence {
+			case ArrowUp:
+				if recall > 0 {
+					recall--
+					guess = guessHistory[recall]
+				}
+			case ArrowDown:
+				if recall < len(guessHistory)-1 {
+					recall++
+					guess = guessHistory[recall]
+				} else {
+					recall = len(guessHistory)
+					guess = ""
+				}
+			case MouseReport:
+				// a click on the keyboard is taken as that key pressed
+				if lastMouse.Pressed && lastMouse.Button == 0 {
+					if key := clickedKey(mouseKeys, stat, lastMouse); key != 0 {
+						queued = append(queued, untranslateInput(key))
+					}
+				}
+			}
+
+			_, _ = stat.WriteString(guessLine(currentGuess), formatGuess(guess, false))
+
+			continue
+		}
+
+		// input was the hints panel toggle
+		if pressed == KeyPanelToggle {
+			showPanel = !showPanel
+			printPanel(stat)
+
+			continue
+		}
+
+		// input was a request for the category hint
+		if pressed == KeyCategoryHint && categoryHintReady() {
+			_, _ = stat.WriteString(statusLine(), "Category: "+wordCategory(word))
+
+			continue
+		}
+
+		// input was the solver assisted toggle
+		if pressed == KeySolverUsed {
+			_, _ = stat.WriteString(statusLine(), gamestats.toggleAssisted())
+
+			continue
 		}
 
-		pressed = unicode.ToUpper(pressed)
+		// input was the trap assistant toggle
+		if pressed == KeyTrapToggle {
+			_, _ = stat.WriteString(statusLine(), gamestats.toggleTrapAssist(practice))
 
-		// _, _ = stat.WriteString(TotalGuesses, fmt.Sprintf("%d", int(pressed))) // debugging tty
+			continue
+		}
 
 		// input was backspace
 		if (pressed == KeyCodeMacBackspace || pressed == KeyCodeWinBackspace) && len(guess) != 0 {
+			typing.Backspace()
+
 			guess = guess[:len(guess)-1]
-			_, _ = stat.WriteString(currentGuess, formatGuess(guess, false))
+			_, _ = stat.WriteString(guessLine(currentGuess), formatGuess(guess, false))
 
 			continue
 		}
 
 		// input was enter and the guess is filled
 		if pressed == KeyCodeEnter && len(guess) == WordLength {
-			if !isWord(guess) {
-				// guess was not a word, indicate error
-				_, _ = stat.WriteString(currentGuess, formatGuess(guess, false)+" (must be a word)")
+			// slow mode, the countdown on the status line says how long
+			if slow != nil && slow.Remaining() > 0 {
+				CueInvalid.play()
+
 				continue
 			}
 
-			// check hard mode requirements
-			if args.HardMode && !hardModeEnforcement(guess) {
-				_, _ = stat.WriteString(currentGuess, formatGuess(guess, false)+" (must use revealed hints)")
+			if rejection := gamestats.checkGuess(guess); rejection != nil {
+				_, _ = stat.WriteString(guessLine(currentGuess), formatGuess(guess, false)+" ("+rejection.Reason+")")
+				CueInvalid.play()
+
 				continue
 			}
 
+			// warn about ruled out letters, pressing enter again submits anyway
+			if args.Novice && guess != confirmed {
+				if wasted := wastedLetters(guess); len(wasted) > 0 {
+					confirmed = guess
+					_, _ = stat.WriteString(guessLine(currentGuess), formatGuess(guess, false)+" (already ruled out: "+strings.Join(wasted, ", ")+", Enter to submit anyway)")
+					CueInvalid.play()
+
+					continue
+				}
+			}
+
 			// show hints
-			_, _ = stat.WriteString(currentGuess, formatGuess(guess, true))
+			_, _ = stat.WriteString(guessLine(currentGuess), formatGuess(guess, true))
+
+			if guess != word {
+				CueRow.play()
+			}
+
+			guessHistory = append(guessHistory, guess)
+			guessTimes = append(guessTimes, time.Since(rowStarted))
+			rowStarted = time.Now()
+			typing.Submit()
+			recall = len(guessHistory)
+
+			if slow != nil {
+				slow.Reset()
+			}
 
 			printKeyboard(stat)
+			printPanel(stat)
+
+			status := []string{}
+
+			if args.Explain {
+				if explanation := explainDuplicates(guess, word, scoreGuess(guess, word)); explanation != "" {
+					status = append(status, explanation)
+				}
+			}
+
+			if trainer {
+				candidates := remainingCandidates(guessHistory, word, wordList)
+				status = append(status, letterFrequency(candidates, guessHistory, 5))
+
+				if args.Suggest != "" && guess != word {
+					state := &SolverState{
+						Guesses:    guessHistory,
+						Candidates: candidates,
+						HardMode:   args.HardMode,
+						Pool:       suggestions,
+					}
+
+					for _, g := range guessHistory {
+						state.Patterns = append(state.Patterns, feedback(g, word).String())
+					}
+
+					// the best probe is no help if hard mode won't take it
+					if args.HardMode {
+						state.Pool = hardModeGuesses(suggestions, candidates, buildConstraints(guessHistory, word))
+					}
+
+					if suggestion := strategy.SuggestGuess(state); suggestion != "" {
+						status = append(status, "try "+suggestion)
+					}
+				}
+			}
+
+			if trapAssist && practice && guess != word {
+				if advice := gamestats.trapAdvice(); advice != "" {
+					status = append(status, advice)
+				}
+			}
+
+			if guess != word && len(guessHistory) == CategoryHintAfter && categoryHintReady() {
+				status = append(status, "press ! for a category hint")
+			}
+
+			if args.Explain || trainer || len(status) > 0 {
+				_, _ = stat.WriteString(statusLine(), strings.Join(status, " | "))
+			}
 
 			if currentGuess == 0 {
 				// just submitted the first guess, this game officially counts
@@ -266,9 +941,25 @@ func main() {
 					gamestats.TotalGames++
 				}
 
+				if opener != "" && guess == opener {
+					gamestats.OpenerGames++
+					openerUsed = true
+				}
+
+				// the planned word is used up once it's been guessed at
+				if planIndex != -1 {
+					gamestats.Plan.Next = planIndex + 1
+				}
+			}
+
+			if shouldPlayDaily {
+				gamestats.trackDaily()
+			}
+
+			if currentGuess == 0 || shouldPlayDaily {
 				err = gamestats.save()
 				if err != nil {
-					_, _ = stat.WriteString(TotalGuesses+1, "(problem saving stats)")
+					_, _ = stat.WriteString(statusLine(), "(problem saving stats)")
 				}
 			}
 
@@ -283,28 +974,65 @@ func main() {
 			guess = ""
 
 			// check for lose
-			if currentGuess == TotalGuesses {
+			if currentGuess == guessBudget {
 				break // exit main loop
 			}
 
 			// update next line with cursor
-			_, _ = stat.WriteString(currentGuess, formatGuess(guess, false))
+			advanceGuessRow(stat)
+			_, _ = stat.WriteString(guessLine(currentGuess), formatGuess(guess, false))
 		}
 
 		// input was letter
-		if len(guess) < WordLength && (unicode.IsLetter(pressed)) {
+		if len(guess) < WordLength && isGuessLetter(pressed) {
+			typing.Key()
+			CueKey.play()
+
 			guess += string(pressed)
-			_, _ = stat.WriteString(currentGuess, formatGuess(guess, false))
+			_, _ = stat.WriteString(guessLine(currentGuess), formatGuess(guess, false))
 		}
 	} // main loop
 
+	if slow != nil {
+		slow.Stop()
+	}
+
 	// cleanup terminal
+	disableMouse()
 	stat.Finish()
 	ty.Close()
 	tyOpen = false
 
+	if strategy != nil {
+		_ = strategy.Close()
+	}
+
+	if planIndex != -1 {
+		gamestats.Plan.reveal(planIndex)
+	}
+
 	// indicate win or lose, update/save/print stats
-	if win {
+	if assisted {
+		// kept apart from the regular stats, streak included
+		gamestats.countAssisted(args.HardMode, win, currentGuess+1)
+
+		if win {
+			fmt.Print("You win! (solver assisted)\n\n")
+		} else {
+			fmt.Printf("\nThe word was %s\n\n", word)
+		}
+	} else if gameMode == GameModeAdaptive {
+		// the budget and word pool change with form, so it's kept apart too
+		gamestats.countAdaptive(args.HardMode, win, currentGuess+1)
+
+		if win {
+			fmt.Print("You win! (adaptive)\n\n")
+		} else {
+			fmt.Printf("\nThe word was %s\n\n", word)
+
+			printLossOdds(guessHistory, word, guessBudget)
+		}
+	} else if win {
 		if args.HardMode {
 			gamestats.HardWins[currentGuess]++
 		} else {
@@ -315,99 +1043,386 @@ func main() {
 		gamestats.BestStreak = int(math.Max(float64(gamestats.BestStreak), float64(gamestats.Streak)))
 
 		fmt.Print("You win!\n\n")
+
+		reachedMilestones = gamestats.checkMilestones()
+		for _, milestone := range reachedMilestones {
+			fmt.Printf("%s\n\n", milestoneBanner(milestone))
+		}
 	} else {
 		gamestats.Streak = 0
 		fmt.Printf("\nThe word was %s\n\n", word)
+
+		printLossOdds(guessHistory, word, guessBudget)
+	}
+
+	if win {
+		CueWin.play()
+	} else {
+		CueLoss.play()
+	}
+
+	if args.LetterRarity {
+		fmt.Printf("%s has rarer letters than %d%% of answers\n\n", word, answerRarity(word))
+	}
+
+	if gamestats.TranscriptChecksum {
+		transcriptNonce = newTranscriptNonce()
+	}
+
+	gamestats.recordGame(win)
+
+	if luck := gamestats.History[len(gamestats.History)-1].Luck; luck != nil {
+		fmt.Printf("Luck: %d/100\n\n", *luck)
+	}
+
+	if difficulty := gamestats.History[len(gamestats.History)-1].Difficulty; difficulty != nil {
+		fmt.Printf("Today's daily difficulty: %d/100\n\n", *difficulty)
+	}
+
+	if shouldPlayDaily && (gamestats.FunFacts || args.Facts) {
+		if fact := wordFact(word); fact != "" {
+			fmt.Printf("%s: %s\n\n", word, fact)
+		}
+	}
+
+	if shouldPlayDaily {
+		gamestats.finishDaily()
 	}
 
 	_ = gamestats.save()
+	gamestats.publishGame()
 
 	gamestats.print(&win)
+
+	waitForCues()
+}
+
+// groupWordIndex derives a daily word index from the group name and the day
+// so every member of a group gets the same word, different from the official
+// daily.
+func groupWordIndex(group string, day int) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(fmt.Sprintf("%s:%d", group, day)))
+
+	return int(h.Sum32() % uint32(len(wordList)))
+}
+
+// openingWord picks the word to pre-fill the first guess with, preferring the
+// --opener flag over the config. Anything that can't be a guess is ignored.
+func openingWord(gamestats *GameStats) string {
+	opener := gamestats.OpeningWord
+	if args.Opener != "" {
+		opener = args.Opener
+	}
+
+	opener = strings.ToUpper(strings.TrimSpace(opener))
+	if len(opener) != WordLength {
+		return ""
+	}
+
+	for _, r := range opener {
+		if r < 'A' || r > 'Z' {
+			return ""
+		}
+	}
+
+	return opener
+}
+
+// isBannedOpener checks the guess against the openers the player has ruled
+// out for themselves, optionally including the previous game's answer.
+func (gs *GameStats) isBannedOpener(guess string) bool {
+	for _, banned := range gs.BannedOpeners {
+		if strings.EqualFold(strings.TrimSpace(banned), guess) {
+			return true
+		}
+	}
+
+	if gs.BanLastAnswer {
+		for i := len(gs.History) - 1; i >= 0; i-- {
+			if gs.History[i].Answer != "" {
+				return gs.History[i].Answer == guess
+			}
+		}
+	}
+
+	return false
+}
+
+// readEscapeSequence consumes the rest of an ANSI escape sequence after the
+// escape key and returns its final character, e.g. ArrowUp, or MouseReport
+// for a click with --mouse. Terminals send a whole sequence at once, so if
+// nothing follows within EscapeTimeout the escape key was pressed on its own
+// and 0 is returned. A key that follows but doesn't start a sequence is
+// returned as other to be handled as usual.
+func readEscapeSequence(ty KeyReader) (final rune, other rune) {
+	r, ok, err := readKeyWithin(ty, EscapeTimeout)
+	if err != nil || !ok {
+		return 0, 0
+	}
+
+	if r != '[' && r != 'O' {
+		return 0, r
+	}
+
+	r, err = ty.ReadKey()
+	if err != nil {
+		return 0, 0
+	}
+
+	if r == MouseReport {
+		report, ok := readMouseReport(ty)
+		if !ok {
+			return 0, 0
+		}
+
+		lastMouse = report
+	}
+
+	return r, 0
+}
+
+// guessLine is the screen line the i-th guess of the game being played is
+// drawn on. The bottom layout puts the keyboard first and keeps the guess
+// being typed on the last line, the finished guesses stacked above it and the
+// rows still to come above those.
+func guessLine(i int) int {
+	if args.Layout != LayoutBottom {
+		return i
+	}
+
+	last := PanelLines + KeyboardRows + boardRows
+	if i > currentGuess {
+		return last - i
+	}
+
+	return last - (currentGuess - i)
+}
+
+// boardLine is the screen line of the i-th guess on a board nobody's typing
+// on, like a replay, where the guesses stay in order under the keyboard in
+// the bottom layout.
+func boardLine(i int) int {
+	if args.Layout == LayoutBottom {
+		return PanelLines + KeyboardRows + 1 + i
+	}
+
+	return i
+}
+
+// advanceGuessRow makes room on the last line for the next guess in the bottom
+// layout by moving the finished guesses up, once currentGuess has moved on.
+func advanceGuessRow(stat *Screen) {
+	if args.Layout != LayoutBottom {
+		return
+	}
+
+	last := guessLine(currentGuess)
+	for line := last - currentGuess; line < last; line++ {
+		_, _ = stat.WriteString(line, stat.Line(line+1))
+	}
+}
+
+// anchorBoard moves the cursor to the bottom of the terminal before a board is
+// drawn in the bottom layout, so the guess being typed is on the last line.
+func anchorBoard() {
+	if args.Layout == LayoutBottom {
+		fmt.Print("\033[999B")
+	}
+}
+
+// statusLine is the screen line for messages, between the guesses and the
+// keyboard.
+func statusLine() int {
+	if args.Layout == LayoutBottom {
+		return PanelLines + KeyboardRows
+	}
+
+	return boardRows
+}
+
+// keyboardLine is the screen line a row of the keyboard is drawn on.
+func keyboardLine(row int) int {
+	if args.Layout == LayoutBottom {
+		return PanelLines + row
+	}
+
+	return boardRows + 1 + row
+}
+
+// panelLine is the screen line of the hints panel, on the far side of the
+// keyboard from the guesses.
+func panelLine() int {
+	if args.Layout == LayoutBottom {
+		return 0
+	}
+
+	return boardRows + 1 + KeyboardRows
+}
+
+// promptLine is the screen line --soft-keyboard reads keys on, below
+// everything else.
+func promptLine() int {
+	return boardRows + 1 + KeyboardRows + PanelLines
+}
+
+// printPanel shows or clears the hints panel.
+func printPanel(stat *Screen) {
+	if !showPanel {
+		_, _ = stat.WriteString(panelLine(), "")
+		return
+	}
+
+	_, _ = stat.WriteString(panelLine(), buildConstraints(guessHistory, word).Summary())
+}
+
+// blankRow draws an empty guess row, rows past the guess budget are crossed
+// out.
+func blankRow(i int) string {
+	if i >= guessBudget {
+		return "     - - - - -"
+	}
+
+	return "     _ _ _ _ _"
+}
+
+// resetGame clears out the previous game so another can be played.
+func resetGame() {
+	currentGuess = 0
+	boardRows = TotalGuesses
+	discovered = make([]bool, WordLength)
+	emojiStack = []string{}
+	guessHistory = []string{}
+	guessTimes = []time.Duration{}
+	typing = TypingTest{}
+
+	initKeyboard()
 }
 
 func initKeyboard() {
 	keyboard = map[rune]KeyHint{}
+	changedKeys = map[rune]bool{}
 
 	for i := 'A'; i <= 'Z'; i++ {
 		keyboard[i] = KeyHintUnknown
 	}
 }
 
-func printKeyboard(stat *statux.Statux) {
-	rows := []string{
-		"QWERTYUIOP",
-		"ASDFGHJKL",
-		"ZXCVBNM",
+func printKeyboard(stat *Screen) {
+	printKeyboardAt(stat, keyboardLine(0))
+}
+
+// printKeyboardAt draws the keyboard starting at the given screen line.
+func printKeyboardAt(stat *Screen, line int) {
+	var known *Constraints
+	if args.PreciseKeyboard {
+		known = buildConstraints(guessHistory, word)
 	}
 
-	for i, row := range rows {
+	for i, row := range keyboardLetters {
 		letters := make([]string, len(row))
 
 		for j, key := range row {
-			sprintf := hintColorFns[keyboard[key]]
+			sprintf := tileColorFns[keyboard[key]]
 			letters[j] = sprintf(string(key))
+
+			if changedKeys[key] && !color.NoColor {
+				letters[j] = "\033[7m" + letters[j] + "\033[27m"
+			}
+
+			// a green key might still have another copy somewhere else
+			if known != nil && keyboard[key] == KeyHintLocated && known.MinCount[byte(key)] > known.LocatedCount(byte(key)) {
+				letters[j] += hintColorFns[KeyHintSomewhere]("+")
+			} else {
+				letters[j] += " "
+			}
+
+			// the number to type for the key with --soft-keyboard
+			if args.SoftKeyboard {
+				letters[j] += fmt.Sprintf("%-3d", softKeyNumber(key))
+			}
+		}
+
+		indent := i
+		if args.SoftKeyboard {
+			indent = i * 2
+		}
+
+		keys := strings.Repeat(" ", indent) + strings.Join(letters, "")
+		if args.Mouse && i == len(keyboardLetters)-1 {
+			keys += MouseEnterCell + " " + MouseDeleteCell
 		}
 
-		lineNumber := TotalGuesses + 1 + i
-		_, _ = stat.WriteString(lineNumber, strings.Repeat(" ", i)+strings.Join(letters, " "))
+		_, _ = stat.WriteString(line+i, keys)
 	}
+
+	flashedKeys = len(changedKeys) > 0
+	changedKeys = map[rune]bool{}
 }
 
+// parseWordLists reads the embedded word lists the first time it's called.
+// Problems with the lists, which only come up when they've been edited, are
+// warned about rather than left for the binary search to trip over.
 func parseWordLists() {
-	// prepare scanner to read embedded memory
-	scanner := bufio.NewScanner(bytes.NewBuffer([]byte(rawGoodWordList)))
-	scanner.Split(bufio.ScanLines)
+	wordListsOnce.Do(func() {
+		var problems []string
 
-	// read in words, we already know how many there are
-	wordList = make([]string, 0, 2309)
-	for scanner.Scan() {
-		wordList = append(wordList, scanner.Text())
-	}
+		wordList, problems = normalizeWordList("good_words.txt", rawGoodWordList)
 
-	// do it again, but keep these words separate
-	scanner = bufio.NewScanner(bytes.NewBuffer([]byte(rawBadWordList)))
-	scanner.Split(bufio.ScanLines)
+		allowed, more := normalizeWordList("bad_words.txt", rawBadWordList)
+		problems = append(problems, more...)
 
-	allowedWords = make([]string, 0, 10657)
-	for scanner.Scan() {
-		allowedWords = append(allowedWords, scanner.Text())
-	}
-}
+		if args.StrictLists {
+			problems = append(problems, checkWordLists(wordList, "bad_words.txt", rawBadWordList)...)
+		}
 
-func formatGuess(guess string, clr bool) string {
-	// map and remove correct guesses
-	m := mapString(word)
+		for _, problem := range problems {
+			fmt.Fprintf(os.Stderr, "warning: %s\n", problem)
+		}
 
-	for i := range guess {
-		if guess[i] == word[i] {
-			m[word[i]]--
+		// the merge into the dictionary needs the allowed words sorted, which
+		// they are unless the list was edited, and checking costs one pass
+		if !sort.StringsAreSorted(allowed) {
+			if !args.StrictLists {
+				fmt.Fprintln(os.Stderr, "warning: bad_words.txt is out of order, wordle check-lists shows where")
+			}
+
+			sort.Strings(allowed)
 		}
-	}
 
+		sortedAnswers = sortAnswers(wordList)
+		dictionary = buildDictionary(sortedAnswers, allowed)
+	})
+}
+
+func formatGuess(guess string, clr bool) string {
 	slots := make([]string, WordLength)
 	emoji := make([]rune, 0, WordLength)
 
+	var hints []KeyHint
+	if clr {
+		hints = scoreGuess(guess, word)
+	}
+
 	for i := range guess {
 		if clr {
-			c := color.RedString
-			if guess[i] == word[i] {
-				c = color.GreenString
-				discovered[i] = true // not elegant, but SUPER convenient
+			c := tileColorFns[KeyHintNotInWord]
 
-				setKeyHint(rune(guess[i]), KeyHintLocated)
+			switch hints[i] {
+			case KeyHintLocated:
+				c = tileColorFns[KeyHintLocated]
+				discovered[i] = true // not elegant, but SUPER convenient
 
 				emoji = append(emoji, EmojiLocated)
-			} else if num := m[guess[i]]; num > 0 {
-				m[guess[i]]--
-				c = color.YellowString
-
-				setKeyHint(rune(guess[i]), KeyHintSomewhere)
+			case KeyHintSomewhere:
+				c = tileColorFns[KeyHintSomewhere]
 				emoji = append(emoji, EmojiSomewhere)
-			} else {
-				setKeyHint(rune(guess[i]), KeyHintNotInWord)
+			default:
 				emoji = append(emoji, EmojiNotInWord)
 			}
 
+			setKeyHint(rune(guess[i]), hints[i])
+
 			slots[i] = c(string(guess[i]))
 		} else {
 			slots[i] = string(guess[i])
@@ -429,13 +1444,48 @@ func formatGuess(guess string, clr bool) string {
 		}
 	}
 
-	return "     " + strings.Join(slots, " ")
+	line := "     " + strings.Join(slots, " ")
+	if clr && args.Braille {
+		line += "  " + brailleFeedback(guess, hints)
+	}
+
+	return line
+}
+
+// scoreGuess grades each letter of the guess against the answer. Exact matches
+// claim their letter first, then the remaining letters are marked as somewhere
+// in the word, left to right, only while unclaimed copies are left.
+func scoreGuess(guess string, answer string) []KeyHint {
+	// map and remove correct guesses
+	m := mapString(answer)
+
+	for i := range guess {
+		if guess[i] == answer[i] {
+			m[answer[i]]--
+		}
+	}
+
+	hints := make([]KeyHint, len(guess))
+
+	for i := range guess {
+		if guess[i] == answer[i] {
+			hints[i] = KeyHintLocated
+		} else if m[guess[i]] > 0 {
+			m[guess[i]]--
+			hints[i] = KeyHintSomewhere
+		} else {
+			hints[i] = KeyHintNotInWord
+		}
+	}
+
+	return hints
 }
 
 func setKeyHint(r rune, hint KeyHint) {
 	existing := keyboard[r]
 	if hint > existing {
 		keyboard[r] = hint
+		changedKeys[r] = true
 	}
 }
 
@@ -463,16 +1513,7 @@ func mapString(str string) map[byte]int {
 
 // isWord checks if a string is a word in the wordlist which makes it a valid guess.
 func isWord(str string) bool {
-	index := sort.SearchStrings(wordList, str)
-	found := index < len(wordList) && wordList[index] == str
-
-	if found {
-		return true
-	}
-
-	index = sort.SearchStrings(allowedWords, str)
-
-	return index < len(allowedWords) && allowedWords[index] == str
+	return dictionary.Contains(str)
 }
 
 func loadGameStats() (gamestats *GameStats) {
@@ -482,19 +1523,37 @@ func loadGameStats() (gamestats *GameStats) {
 		HardWins: make([]int, TotalGuesses),
 	}
 
+	// nothing is remembered, so every game is a fresh start at today's daily
+	if args.NoStats {
+		return gamestats
+	}
+
 	// load stats
-	home, err := os.UserHomeDir()
+	savePath, fallback, err := statsPath()
+	if fallback {
+		warnStatsPath(savePath, err)
+	}
+
 	if err != nil {
 		return gamestats
 	}
 
-	savePath := path.Join(home, ".wordle")
-
 	raw, err := ioutil.ReadFile(savePath)
 	if err != nil {
+		if args.EncryptStats {
+			gamestats.encryptFromNowOn()
+		}
+
 		return gamestats
 	}
 
+	// an encrypted file that can't be read is never deleted
+	raw, encrypted, err := decryptStats(raw)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
 	err = json.NewDecoder(bytes.NewReader(raw)).Decode(&gamestats)
 	if err != nil {
 		// file present but unreadable? delete it.
@@ -502,16 +1561,38 @@ func loadGameStats() (gamestats *GameStats) {
 		return gamestats
 	}
 
+	gamestats.encrypted = encrypted
+	if args.EncryptStats && !encrypted {
+		gamestats.encryptFromNowOn()
+	}
+
+	gamestats.migrateHistory()
+	gamestats.migrateDailies()
+
 	return gamestats
 }
 
 func (gs *GameStats) save() error {
-	home, err := os.UserHomeDir()
+	if args.NoStats {
+		return nil
+	}
+
+	savePath, _, err := statsPath()
 	if err != nil {
 		return err
 	}
 
-	savePath := path.Join(home, ".wordle")
+	raw, err := json.Marshal(gs)
+	if err != nil {
+		return err
+	}
+
+	if gs.encrypted {
+		raw, err = encryptStats(raw)
+		if err != nil {
+			return err
+		}
+	}
 
 	f, err := os.OpenFile(savePath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
 	if err != nil {
@@ -519,7 +1600,7 @@ func (gs *GameStats) save() error {
 	}
 	defer f.Close()
 
-	err = json.NewEncoder(f).Encode(gs)
+	_, err = f.Write(append(raw, '\n'))
 	if err != nil {
 		return err
 	}
@@ -565,6 +1646,46 @@ func (gs *GameStats) print(win *bool) {
 
 	fmt.Printf("Current Streak: %d\n", gs.Streak)
 	fmt.Printf("   Best Streak: %d\n", gs.BestStreak)
+
+	if gs.OpenerGames > 0 {
+		fmt.Printf("   Opener Used: %d\n", gs.OpenerGames)
+	}
+
+	if len(gs.Achievements) > 0 {
+		names := make([]string, len(gs.Achievements))
+		for i, achievement := range gs.Achievements {
+			names[i] = achievement.String()
+		}
+
+		fmt.Printf("    Milestones: %s\n", strings.Join(names, ", "))
+	}
+
+	if gs.Assisted.Games > 0 {
+		fmt.Printf("      Assisted: %d (not counted above)\n", gs.Assisted.Games)
+	}
+
+	if gs.Adaptive.Games > 0 {
+		fmt.Printf("      Adaptive: %d (not counted above)\n", gs.Adaptive.Games)
+	}
+
+	if rarity := gs.averageRarity(); rarity >= 0 && args.LetterRarity {
+		fmt.Printf(" Letter Rarity: %d%% on average\n", rarity)
+	}
+
+	if luck := gs.averageLuck(); luck >= 0 {
+		fmt.Printf("      Avg Luck: %d/100\n", luck)
+	}
+
+	if overall, recent := gs.averageDifficulty(); overall >= 0 {
+		fmt.Printf("Avg Difficulty: %d/100", overall)
+
+		if recent >= 0 {
+			fmt.Printf(" (last %d dailies: %d)", DifficultyRecent, recent)
+		}
+
+		fmt.Println()
+	}
+
 	fmt.Println()
 	fmt.Print("Guess Distribution:\n\n")
 
@@ -575,7 +1696,10 @@ func (gs *GameStats) print(win *bool) {
 	winPadding := 0
 
 	for i := 0; i < TotalGuesses; i++ {
-		hist[i] = float64(wins[i]) / float64(totalWins)
+		if totalWins > 0 {
+			hist[i] = float64(wins[i]) / float64(totalWins)
+		}
+
 		if max < hist[i] {
 			max = hist[i]
 		}
@@ -587,7 +1711,10 @@ func (gs *GameStats) print(win *bool) {
 		}
 	}
 
-	mult := MaxHistogramBarLength / max
+	mult := float64(0)
+	if max > 0 {
+		mult = MaxHistogramBarLength / max
+	}
 
 	// histogram
 	for i := 0; i < TotalGuesses; i++ {
@@ -596,7 +1723,7 @@ func (gs *GameStats) print(win *bool) {
 		fmt.Printf("%d: %s %s\n", i+1, count, strings.Repeat("█", int(math.Min(MaxHistogramBarLength, hist[i]*mult))))
 	}
 
-	if gs.ExperimentalEmojiSupport && win != nil {
+	if gs.useEmoji() && win != nil {
 		fmt.Println()
 
 		turn := "X"
@@ -605,12 +1732,99 @@ func (gs *GameStats) print(win *bool) {
 			turn = strconv.Itoa(currentGuess + 1)
 		}
 
-		fmt.Printf("Wordle %d %s/6%s\n\n", dayOffset, turn, hardInd)
+		level := gs.shareLevel()
 
-		for _, line := range emojiStack {
-			fmt.Println(line)
+		title := "Wordle"
+		if args.Group != "" && level == ShareFull {
+			title += " " + args.Group
 		}
+
+		header := fmt.Sprintf("%s %d %s/%d%s", title, gs.puzzleNumber(), turn, guessBudget, hardInd)
+
+		switch level {
+		case ShareMinimal:
+			header = fmt.Sprintf("%s %d %s/%d", title, gs.puzzleNumber(), turn, guessBudget)
+		case ShareAnonymous:
+			header = fmt.Sprintf("%s %s/%d%s", title, turn, guessBudget, hardInd)
+		}
+
+		if assisted {
+			header += " (solver assisted)"
+		}
+
+		fmt.Println(header)
+
+		if level != ShareMinimal {
+			fmt.Println()
+
+			block := []string{header}
+
+			for i, line := range emojiStack {
+				if level == ShareFull && gs.ShareTiming && i < len(guessTimes) && guessTimes[i] > 0 {
+					line += fmt.Sprintf(" %ds", int(guessTimes[i].Round(time.Second).Seconds()))
+				}
+
+				if level == ShareFull && args.Typing && i < len(typing.WPM) && typing.WPM[i] > 0 {
+					line += fmt.Sprintf(" %.0fwpm", typing.WPM[i])
+				}
+
+				line = gs.shareLine(line)
+				fmt.Println(line)
+
+				block = append(block, line)
+			}
+
+			if gs.ShareKeys {
+				line := gs.shareLine(keyboardSummary())
+				fmt.Println(line)
+
+				block = append(block, line)
+			}
+
+			for _, milestone := range reachedMilestones {
+				line := gs.shareLine(milestone.shareLine())
+				fmt.Println(line)
+
+				block = append(block, line)
+			}
+
+			if level == ShareFull && gs.SignShares {
+				var elapsed time.Duration
+				for _, d := range guessTimes {
+					elapsed += d
+				}
+
+				seconds := int(elapsed.Round(time.Second).Seconds())
+
+				key, err := loadShareKey(true)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "warning: share not signed: %v\n", err)
+				} else {
+					fmt.Printf("%s %ds %s\n", ShareSignaturePrefix, seconds, signShare(key, block, seconds))
+				}
+			}
+		}
+	}
+
+	if transcriptNonce != "" && win != nil {
+		fmt.Printf("\nTranscript checksum: %s\n", transcriptChecksum(gameTranscript(gs.puzzleNumber(), transcriptNonce, guessHistory)))
 	}
+
+	if args.Typing && win != nil {
+		fmt.Println()
+		fmt.Println(typing.Summary(*win))
+	}
+}
+
+// puzzleNumber is the number shown when sharing a game. The daily rotation
+// doesn't line up with the official numbering so it can be shifted by the
+// config or overridden outright with --number.
+func (gs *GameStats) puzzleNumber() int {
+	if args.Number != 0 {
+		return args.Number
+	}
+
+	return dayOffset + gs.PuzzleNumberOffset
 }
 
 func printUsage() {
@@ -618,7 +1832,7 @@ func printUsage() {
 	fmt.Println("Each guess must be a valid word. Submit with Enter: Red letters aren't in the answer,")
 	fmt.Println("yellow letters are in the answer, green letters are in the answer at that position.")
 	fmt.Println("Hard mode: once a letter is green, all future guesses must include those letters in")
-	fmt.Println("those positions.")
+	fmt.Println("those positions, and yellow letters must be used somewhere.")
 	fmt.Println()
 	fmt.Printf("Wordle v%s\n", version)
 }
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	MatrixSyncTimeout = 30 * time.Second
+	MatrixRetryDelay  = 5 * time.Second
+)
+
+type MatrixCommand struct {
+	Homeserver string `long:"homeserver" required:"true" description:"URL of the bot account's homeserver" value-name:"URL"`
+	Token      string `long:"token" required:"true" description:"Access token of the bot account" value-name:"T"`
+	Room       string `long:"room" required:"true" description:"Room ID or alias to play in" value-name:"ROOM"`
+}
+
+// matrixBot talks to a homeserver with the client-server API, as a regular
+// user account that's been invited to the room.
+type matrixBot struct {
+	homeserver string
+	token      string
+	client     *http.Client
+	user       string
+	txn        int64
+}
+
+type matrixSync struct {
+	NextBatch string `json:"next_batch"`
+	Rooms     struct {
+		Join map[string]struct {
+			Timeline struct {
+				Events []matrixEvent `json:"events"`
+			} `json:"timeline"`
+		} `json:"join"`
+	} `json:"rooms"`
+}
+
+type matrixEvent struct {
+	Type    string `json:"type"`
+	Sender  string `json:"sender"`
+	Content struct {
+		MsgType string `json:"msgtype"`
+		Body    string `json:"body"`
+	} `json:"content"`
+}
+
+func (cmd *MatrixCommand) Execute(_ []string) error {
+	gamestats := loadGameStats()
+	parseWordLists()
+
+	bot := &matrixBot{
+		homeserver: strings.TrimRight(cmd.Homeserver, "/"),
+		token:      cmd.Token,
+		client:     &http.Client{Timeout: MatrixSyncTimeout + 10*time.Second},
+		txn:        time.Now().UnixNano(),
+	}
+
+	var whoami struct {
+		UserID string `json:"user_id"`
+	}
+
+	err := bot.call(http.MethodGet, "/account/whoami", nil, &whoami)
+	if err != nil {
+		return err
+	}
+
+	bot.user = whoami.UserID
+
+	var joined struct {
+		RoomID string `json:"room_id"`
+	}
+
+	err = bot.call(http.MethodPost, "/join/"+url.PathEscape(cmd.Room), struct{}{}, &joined)
+	if err != nil {
+		return err
+	}
+
+	room := joined.RoomID
+	name := "matrix:" + room
+
+	fmt.Println("Matrix bot running, press Ctrl+C to stop")
+
+	// the first sync is only to skip whatever was said before the bot started
+	since := ""
+
+	for {
+		sync, err := bot.sync(room, since)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			time.Sleep(MatrixRetryDelay)
+
+			continue
+		}
+
+		if since == "" {
+			since = sync.NextBatch
+			continue
+		}
+
+		since = sync.NextBatch
+
+		for _, event := range sync.Rooms.Join[room].Timeline.Events {
+			if event.Type != "m.room.message" || event.Content.MsgType != "m.text" || event.Sender == bot.user {
+				continue
+			}
+
+			if !strings.HasPrefix(event.Content.Body, "!") {
+				continue
+			}
+
+			reply, err := gamestats.playChat(name, event.Sender, event.Content.Body, "!")
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "warning: chat stats: %v\n", err)
+			}
+
+			if reply == "" {
+				continue
+			}
+
+			err = bot.send(room, reply)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+			}
+		}
+	}
+}
+
+// sync waits for new messages in the room.
+func (bot *matrixBot) sync(room string, since string) (*matrixSync, error) {
+	filter, _ := json.Marshal(map[string]interface{}{
+		"room": map[string]interface{}{
+			"rooms":    []string{room},
+			"timeline": map[string]interface{}{"types": []string{"m.room.message"}},
+		},
+	})
+
+	query := url.Values{}
+	query.Set("filter", string(filter))
+
+	if since != "" {
+		query.Set("since", since)
+		query.Set("timeout", strconv.Itoa(int(MatrixSyncTimeout.Milliseconds())))
+	}
+
+	sync := &matrixSync{}
+
+	err := bot.call(http.MethodGet, "/sync?"+query.Encode(), nil, sync)
+	if err != nil {
+		return nil, err
+	}
+
+	return sync, nil
+}
+
+// send posts a reply to the room as a notice, which other bots know to ignore.
+func (bot *matrixBot) send(room string, text string) error {
+	bot.txn++
+
+	path := fmt.Sprintf("/rooms/%s/send/m.room.message/%d", url.PathEscape(room), bot.txn)
+	content := map[string]string{"msgtype": "m.notice", "body": text}
+
+	return bot.call(http.MethodPut, path, content, nil)
+}
+
+// call makes a client-server API request, sending body and decoding the
+// response into result when they're given.
+func (bot *matrixBot) call(method string, path string, body interface{}, result interface{}) error {
+	var payload bytes.Buffer
+
+	if body != nil {
+		err := json.NewEncoder(&payload).Encode(body)
+		if err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequest(method, bot.homeserver+"/_matrix/client/v3"+path, &payload)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+bot.token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := bot.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var matrixErr struct {
+			ErrCode string `json:"errcode"`
+			Error   string `json:"error"`
+		}
+
+		_ = json.NewDecoder(resp.Body).Decode(&matrixErr)
+
+		return fmt.Errorf("matrix: %s %s: %s %s", method, strings.SplitN(path, "?", 2)[0], resp.Status, matrixErr.Error)
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MilestoneCounts are the streaks and total wins worth celebrating.
+var MilestoneCounts = []int{10, 50, 100, 365}
+
+const (
+	MilestoneStreak = "streak"
+	MilestoneWins   = "wins"
+)
+
+// Achievement is a milestone reached, kept in the stats the first time it's
+// reached so the stats can list them.
+type Achievement struct {
+	Kind  string    `json:"kind"`
+	Count int       `json:"count"`
+	Date  time.Time `json:"date"`
+}
+
+func (a Achievement) String() string {
+	if a.Kind == MilestoneStreak {
+		return fmt.Sprintf("%d game streak", a.Count)
+	}
+
+	return fmt.Sprintf("%d wins", a.Count)
+}
+
+// shareLine is the achievement as a line for the share block.
+func (a Achievement) shareLine() string {
+	if a.Kind == MilestoneStreak {
+		return "🔥 " + a.String()
+	}
+
+	return "🏆 " + a.String()
+}
+
+// reachedMilestones are the milestones the game just played reached.
+var reachedMilestones []Achievement
+
+// checkMilestones finds the milestones a win just reached, recording the ones
+// reached for the first time. Winning streaks can reach the same milestone
+// again after being broken, which is celebrated but not recorded twice.
+func (gs *GameStats) checkMilestones() []Achievement {
+	wins := gs.winCount()
+	for _, count := range gs.HardWins {
+		wins += count
+	}
+
+	reached := []Achievement{}
+
+	for _, count := range MilestoneCounts {
+		if gs.Streak == count {
+			reached = append(reached, Achievement{Kind: MilestoneStreak, Count: count, Date: time.Now()})
+		}
+
+		if wins == count {
+			reached = append(reached, Achievement{Kind: MilestoneWins, Count: count, Date: time.Now()})
+		}
+	}
+
+	for _, achievement := range reached {
+		if !gs.hasAchievement(achievement.Kind, achievement.Count) {
+			gs.Achievements = append(gs.Achievements, achievement)
+		}
+	}
+
+	return reached
+}
+
+func (gs *GameStats) hasAchievement(kind string, count int) bool {
+	for _, achievement := range gs.Achievements {
+		if achievement.Kind == kind && achievement.Count == count {
+			return true
+		}
+	}
+
+	return false
+}
+
+// milestoneBanner celebrates a milestone after the game.
+func milestoneBanner(a Achievement) string {
+	text := "  " + strings.ToUpper(a.String()) + "!  "
+	rule := strings.Repeat("*", len(text)+2)
+
+	return fmt.Sprintf("%s\n*%s*\n%s", rule, text, rule)
+}
@@ -0,0 +1,248 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// MouseReport is what readEscapeSequence returns for a mouse report, the
+	// report itself is left in lastMouse.
+	MouseReport = '<'
+
+	// MouseEnterCell and MouseDeleteCell follow the bottom row of the
+	// on-screen keyboard with --mouse, to click in place of Enter and
+	// backspace.
+	MouseEnterCell  = "ENTER"
+	MouseDeleteCell = "⌫"
+
+	// CursorReportTimeout is how long the terminal has to say where the
+	// cursor is. Terminals that never answer don't get clicks mapped to keys.
+	CursorReportTimeout = 500 * time.Millisecond
+)
+
+// mouseReport is a click as the terminal reports it, columns and rows
+// counted from 1 at the top left of the terminal.
+type mouseReport struct {
+	Button  int
+	Col     int
+	Row     int
+	Pressed bool
+}
+
+// lastMouse is the mouse report readEscapeSequence read last.
+var lastMouse mouseReport
+
+// enableMouse has the terminal report clicks with --mouse, in the SGR
+// encoding xterm and most terminals since support, so they aren't limited to
+// the first 223 columns.
+func enableMouse() {
+	if args.Mouse {
+		fmt.Print("\033[?1000h\033[?1006h")
+	}
+}
+
+// disableMouse puts the terminal back to selecting text with the mouse.
+func disableMouse() {
+	if args.Mouse {
+		fmt.Print("\033[?1006l\033[?1000l")
+	}
+}
+
+// readMouseReport reads the rest of a report after its ESC [ <, like 0;12;30M
+// for the left button pressed at column 12 of row 30. It's m instead of M
+// when the button is let go.
+func readMouseReport(ty KeyReader) (mouseReport, bool) {
+	params, final := readEscapeParams(ty)
+	if (final != 'M' && final != 'm') || len(params) != 3 {
+		return mouseReport{}, false
+	}
+
+	return mouseReport{Button: params[0], Col: params[1], Row: params[2], Pressed: final == 'M'}, true
+}
+
+// readEscapeParams reads the numbers of an escape sequence separated by
+// semicolons up to its final character.
+func readEscapeParams(ty KeyReader) ([]int, rune) {
+	var digits strings.Builder
+
+	for {
+		r, err := ty.ReadKey()
+		if err != nil {
+			return nil, 0
+		}
+
+		if (r >= '0' && r <= '9') || r == ';' {
+			digits.WriteRune(r)
+			continue
+		}
+
+		params := []int{}
+
+		for _, field := range strings.Split(digits.String(), ";") {
+			n, err := strconv.Atoi(field)
+			if err != nil {
+				return nil, r
+			}
+
+			params = append(params, n)
+		}
+
+		return params, r
+	}
+}
+
+// pushbackKeys reads keys with room to hand back ones read too early, like
+// keys pressed while waiting on the terminal to say where the cursor is.
+type pushbackKeys struct {
+	KeyReader
+	pending []rune
+
+	// unanswered is set once the terminal hasn't said where the cursor is,
+	// so it isn't asked again on every click
+	unanswered bool
+}
+
+func (keys *pushbackKeys) ReadKey() (rune, error) {
+	if len(keys.pending) > 0 {
+		r := keys.pending[0]
+		keys.pending = keys.pending[1:]
+
+		return r, nil
+	}
+
+	return keys.KeyReader.ReadKey()
+}
+
+func (keys *pushbackKeys) ReadKeyWithin(timeout time.Duration) (rune, bool, error) {
+	if len(keys.pending) > 0 {
+		r, _ := keys.ReadKey()
+
+		return r, true, nil
+	}
+
+	return readKeyWithin(keys.KeyReader, timeout)
+}
+
+// cursorRow asks the terminal which row the cursor is on and reads the
+// answer, which comes back like a key as ESC [ row ; col R. Anything else
+// that comes in first, even part of a mouse report, is handed back to be read
+// as usual. If no answer comes within CursorReportTimeout the terminal isn't
+// asked again.
+func cursorRow(keys *pushbackKeys) (int, bool) {
+	if keys.unanswered {
+		return 0, false
+	}
+
+	fmt.Print("\033[6n")
+
+	deadline := time.Now().Add(CursorReportTimeout)
+	seq := []rune{}
+
+	for {
+		r, ok, err := readKeyWithin(keys.KeyReader, time.Until(deadline))
+		if err != nil || !ok {
+			keys.pending = append(keys.pending, seq...)
+			keys.unanswered = err == nil
+
+			return 0, false
+		}
+
+		if r == KeyCodeEscape {
+			keys.pending = append(keys.pending, seq...)
+			seq = []rune{r}
+
+			continue
+		}
+
+		seq = append(seq, r)
+
+		switch {
+		case len(seq) == 2 && r == '[':
+			continue
+		case len(seq) > 2 && ((r >= '0' && r <= '9') || r == ';'):
+			continue
+		case len(seq) > 2 && r == 'R':
+			if row, ok := parseCursorReport(seq); ok {
+				return row, true
+			}
+		}
+
+		keys.pending = append(keys.pending, seq...)
+		seq = seq[:0]
+	}
+}
+
+// parseCursorReport reads the row out of ESC [ row ; col R.
+func parseCursorReport(seq []rune) (int, bool) {
+	fields := strings.Split(string(seq[2:len(seq)-1]), ";")
+	if len(fields) != 2 {
+		return 0, false
+	}
+
+	row, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return 0, false
+	}
+
+	return row, true
+}
+
+// locateBoard asks the terminal where the board is, once when the game starts
+// and again after it's been redrawn, rather than for every click.
+func locateBoard(keys *pushbackKeys, stat *Screen) {
+	if row, ok := cursorRow(keys); ok {
+		stat.SetTop(row)
+	}
+}
+
+// clickedKey finds the key of the on-screen keyboard a click landed on, or 0
+// if it missed. The terminal reports where the click was on the whole
+// terminal, so it's measured from the row the board starts on.
+func clickedKey(keys *pushbackKeys, stat *Screen, click mouseReport) rune {
+	if stat.Top() == 0 {
+		locateBoard(keys, stat)
+	}
+
+	if stat.Top() == 0 {
+		return 0
+	}
+
+	line := click.Row - stat.Top()
+
+	for i := range keyboardLetters {
+		if line == keyboardLine(i) {
+			return keyAt(i, click.Col-1)
+		}
+	}
+
+	return 0
+}
+
+// keyAt is the key drawn at a column of a row of the on-screen keyboard, as
+// printKeyboardAt lays them out: two columns a key, each row in by one more.
+func keyAt(row int, col int) rune {
+	letters := keyboardLetters[row]
+
+	offset := col - row
+	if offset >= 0 && offset/2 < len(letters) {
+		return rune(letters[offset/2])
+	}
+
+	if row != len(keyboardLetters)-1 {
+		return 0
+	}
+
+	start := row + 2*len(letters)
+
+	switch {
+	case col >= start && col < start+len(MouseEnterCell):
+		return KeyCodeEnter
+	case col == start+len(MouseEnterCell)+1:
+		return KeyCodeMacBackspace
+	default:
+		return 0
+	}
+}
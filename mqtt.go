@@ -0,0 +1,265 @@
+package main
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	MQTTTimeout      = 3 * time.Second
+	MQTTDefaultTopic = "wordle"
+
+	// MQTTQueueLimit is the most messages kept for a broker that can't be
+	// reached, the oldest are dropped past it.
+	MQTTQueueLimit = 500
+
+	// MQTTPasswordEnv holds the password for mqtt_username, so it isn't kept
+	// in the stats file where anyone who can read it would see it.
+	MQTTPasswordEnv = "WORDLE_MQTT_PASSWORD"
+)
+
+// mqttMessage is a message to publish. Retained messages are handed to
+// anything that subscribes later, so dashboards show the latest value even if
+// they weren't connected when it was sent.
+type mqttMessage struct {
+	topic   string
+	payload []byte
+	retain  bool
+}
+
+// QueuedMQTTMessage is a message that couldn't be published, kept in the
+// stats file until the broker can be reached again.
+type QueuedMQTTMessage struct {
+	Topic   string    `json:"topic"`
+	Payload []byte    `json:"payload"`
+	Retain  bool      `json:"retain,omitempty"`
+	Queued  time.Time `json:"queued"`
+}
+
+// mqttGameEvent is published to <topic>/game when a game ends.
+type mqttGameEvent struct {
+	Mode       string `json:"mode"`
+	Won        bool   `json:"won"`
+	Guesses    int    `json:"guesses"`
+	HardMode   bool   `json:"hard_mode"`
+	Streak     int    `json:"streak"`
+	BestStreak int    `json:"best_streak"`
+}
+
+// publishGame sends the game that was just recorded to the MQTT broker in the
+// config, if there is one. Problems are only warned about, home automation
+// shouldn't get in the way of the game. Messages that couldn't be sent, like
+// on a laptop that's offline, are queued in the stats file and sent ahead of
+// the next game's, so games played offline still reach the broker.
+func (gs *GameStats) publishGame() {
+	if gs.MQTTBroker == "" || len(gs.History) == 0 {
+		return
+	}
+
+	topic := gs.MQTTTopic
+	if topic == "" {
+		topic = MQTTDefaultTopic
+	}
+
+	game := gs.History[len(gs.History)-1]
+
+	event, _ := json.Marshal(mqttGameEvent{
+		Mode:       game.Mode,
+		Won:        game.Won,
+		Guesses:    len(game.Guesses),
+		HardMode:   game.HardMode,
+		Streak:     gs.Streak,
+		BestStreak: gs.BestStreak,
+	})
+
+	messages := []mqttMessage{
+		{topic: topic + "/game", payload: event},
+		{topic: topic + "/streak", payload: []byte(strconv.Itoa(gs.Streak)), retain: true},
+	}
+
+	if game.Mode == GameModeDaily {
+		messages = append(messages, mqttMessage{topic: topic + "/daily", payload: []byte(game.Date.Format("2006-01-02")), retain: true})
+	}
+
+	queued := len(gs.MQTTQueue)
+	pending := make([]mqttMessage, 0, queued+len(messages))
+
+	for _, msg := range gs.MQTTQueue {
+		pending = append(pending, mqttMessage{topic: msg.Topic, payload: msg.Payload, retain: msg.Retain})
+	}
+
+	sent, err := mqttPublish(gs.MQTTBroker, gs.MQTTUsername, os.Getenv(MQTTPasswordEnv), append(pending, messages...))
+	if err != nil {
+		// only what didn't make it is queued, the queue first since it was
+		// sent first
+		if sent < queued {
+			gs.MQTTQueue = gs.MQTTQueue[sent:]
+			gs.queueMQTT(messages)
+		} else {
+			gs.MQTTQueue = nil
+			gs.queueMQTT(messages[sent-queued:])
+		}
+
+		_ = gs.save()
+
+		fmt.Fprintf(os.Stderr, "warning: mqtt: %s, queued %d messages to send after the next game\n", err, len(gs.MQTTQueue))
+
+		return
+	}
+
+	if queued > 0 {
+		gs.MQTTQueue = nil
+		_ = gs.save()
+	}
+}
+
+// queueMQTT keeps messages to send later. A retained message replaces any
+// queued one for the same topic since the broker would only keep the last.
+func (gs *GameStats) queueMQTT(messages []mqttMessage) {
+	now := time.Now()
+
+	for _, msg := range messages {
+		if msg.retain {
+			kept := gs.MQTTQueue[:0]
+
+			for _, queued := range gs.MQTTQueue {
+				if !queued.Retain || queued.Topic != msg.topic {
+					kept = append(kept, queued)
+				}
+			}
+
+			gs.MQTTQueue = kept
+		}
+
+		gs.MQTTQueue = append(gs.MQTTQueue, QueuedMQTTMessage{
+			Topic:   msg.topic,
+			Payload: msg.payload,
+			Retain:  msg.retain,
+			Queued:  now,
+		})
+	}
+
+	if len(gs.MQTTQueue) > MQTTQueueLimit {
+		gs.MQTTQueue = gs.MQTTQueue[len(gs.MQTTQueue)-MQTTQueueLimit:]
+	}
+}
+
+// mqttPublish connects to a broker, publishes the messages, and disconnects.
+// It's only as much of MQTT 3.1.1 as publishing at QoS 0 needs. sent is how
+// many of the messages were published before anything went wrong.
+func mqttPublish(broker string, username string, password string, messages []mqttMessage) (sent int, err error) {
+	conn, err := net.DialTimeout("tcp", broker, MQTTTimeout)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	_ = conn.SetDeadline(time.Now().Add(MQTTTimeout))
+
+	id := make([]byte, 4)
+	_, _ = rand.Read(id)
+
+	var connect bytes.Buffer
+
+	flags := byte(0x02) // clean session
+	if username != "" {
+		flags |= 0x80
+	}
+
+	if password != "" {
+		flags |= 0x40
+	}
+
+	writeMQTTString(&connect, "MQTT")
+	connect.Write([]byte{4, flags, 0, 60}) // protocol level 4, 60s keep alive
+	writeMQTTString(&connect, "wordle-"+hex.EncodeToString(id))
+
+	if username != "" {
+		writeMQTTString(&connect, username)
+	}
+
+	if password != "" {
+		writeMQTTString(&connect, password)
+	}
+
+	err = writeMQTTPacket(conn, 0x10, connect.Bytes())
+	if err != nil {
+		return 0, err
+	}
+
+	connack := make([]byte, 4)
+
+	_, err = io.ReadFull(conn, connack)
+	if err != nil {
+		return 0, err
+	}
+
+	if connack[0] != 0x20 {
+		return 0, fmt.Errorf("expected CONNACK from %s", broker)
+	}
+
+	if connack[3] != 0 {
+		return 0, fmt.Errorf("%s refused the connection, code %d", broker, connack[3])
+	}
+
+	for _, msg := range messages {
+		var publish bytes.Buffer
+
+		writeMQTTString(&publish, msg.topic)
+		publish.Write(msg.payload)
+
+		header := byte(0x30)
+		if msg.retain {
+			header |= 0x01
+		}
+
+		err = writeMQTTPacket(conn, header, publish.Bytes())
+		if err != nil {
+			return sent, err
+		}
+
+		sent++
+	}
+
+	return sent, writeMQTTPacket(conn, 0xE0, nil)
+}
+
+// writeMQTTPacket writes a control packet, its remaining length is encoded
+// seven bits at a time.
+func writeMQTTPacket(w io.Writer, header byte, body []byte) error {
+	packet := []byte{header}
+
+	length := len(body)
+
+	for {
+		b := byte(length % 128)
+		length /= 128
+
+		if length > 0 {
+			b |= 0x80
+		}
+
+		packet = append(packet, b)
+
+		if length == 0 {
+			break
+		}
+	}
+
+	_, err := w.Write(append(packet, body...))
+
+	return err
+}
+
+func writeMQTTString(b *bytes.Buffer, s string) {
+	b.Write([]byte{byte(len(s) >> 8), byte(len(s))})
+	b.WriteString(s)
+}
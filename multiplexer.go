@@ -0,0 +1,89 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Terminal multiplexers, which sit between the game and the real terminal
+// and handle some escape sequences differently.
+const (
+	MultiplexerAuto   = "auto"
+	MultiplexerTmux   = "tmux"
+	MultiplexerScreen = "screen"
+	MultiplexerNone   = "none"
+)
+
+// multiplexer is the multiplexer the game runs in, worked out once.
+var multiplexer string
+
+// detectMultiplexer works out which multiplexer, if any, the game is running
+// in. --multiplexer can say so for cases that can't be told apart, e.g. after
+// SSHing out of tmux only TERM is left to go on.
+func detectMultiplexer() string {
+	if multiplexer != "" {
+		return multiplexer
+	}
+
+	multiplexer = MultiplexerNone
+
+	switch {
+	case args.Multiplexer != "" && args.Multiplexer != MultiplexerAuto:
+		multiplexer = args.Multiplexer
+	case os.Getenv("TMUX") != "":
+		multiplexer = MultiplexerTmux
+	case os.Getenv("STY") != "":
+		multiplexer = MultiplexerScreen
+	case strings.HasPrefix(os.Getenv("TERM"), "tmux"):
+		multiplexer = MultiplexerTmux
+	case strings.HasPrefix(os.Getenv("TERM"), "screen"):
+		multiplexer = MultiplexerScreen
+	}
+
+	return multiplexer
+}
+
+// passthrough wraps an escape sequence so the multiplexer hands it on to the
+// real terminal instead of answering or dropping it. tmux only passes it on
+// with allow-passthrough turned on. The sequence can't end with ST (ESC \),
+// screen would take it as the end of the wrapper.
+func passthrough(seq string) string {
+	switch detectMultiplexer() {
+	case MultiplexerTmux:
+		return "\x1bPtmux;" + strings.ReplaceAll(seq, "\x1b", "\x1b\x1b") + "\x1b\\"
+	case MultiplexerScreen:
+		return "\x1bP" + seq + "\x1b\\"
+	}
+
+	return seq
+}
+
+// multiplexerTruecolor checks whether the multiplexer passes 24 bit color on.
+// COLORTERM is inherited from the outer terminal so it can't be trusted. tmux
+// can be asked what the client terminal supports, screen is assumed not to.
+func multiplexerTruecolor() bool {
+	switch detectMultiplexer() {
+	case MultiplexerTmux:
+		if os.Getenv("TMUX") == "" {
+			return false
+		}
+
+		out, err := exec.Command("tmux", "display-message", "-p", "#{client_termfeatures}").Output()
+		if err != nil {
+			return false
+		}
+
+		for _, feature := range strings.Split(strings.TrimSpace(string(out)), ",") {
+			if feature == "RGB" {
+				return true
+			}
+		}
+
+		return false
+	case MultiplexerScreen:
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// OddsTrials is how many games are simulated from each position of a loss.
+const OddsTrials = 1000
+
+// printLossOdds softens the blow of a loss by estimating, for the position
+// after each guess, how often a typical player would have won from there. A
+// typical player here guesses any word that fits everything known so far,
+// against the answer the game really had.
+func printLossOdds(guesses []string, answer string, budget int) {
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	candidates := newCandidateSet(wordList)
+	lines := []string{}
+
+	for i, guess := range guesses {
+		left := budget - i - 1
+		if left <= 0 {
+			break
+		}
+
+		candidates = candidates.filter(guess, feedback(guess, answer))
+
+		wins := 0
+
+		for trial := 0; trial < OddsTrials; trial++ {
+			if simulateTypicalPlayer(candidates, answer, left, rng) {
+				wins++
+			}
+		}
+
+		lines = append(lines, fmt.Sprintf("  after %s  %d%%", guess, wins*100/OddsTrials))
+	}
+
+	if len(lines) == 0 {
+		return
+	}
+
+	fmt.Println("Odds a typical player would have won from there:")
+
+	for _, line := range lines {
+		fmt.Println(line)
+	}
+
+	fmt.Println()
+}
+
+// simulateTypicalPlayer plays one game from a position, guessing at random
+// from the candidates, and reports whether the answer was found within the
+// guesses left.
+func simulateTypicalPlayer(candidates candidateSet, answer string, left int, rng *rand.Rand) bool {
+	for ; left > 0; left-- {
+		guess := candidates.words[rng.Intn(len(candidates.words))]
+		if guess == answer {
+			return true
+		}
+
+		candidates = candidates.filter(guess, feedback(guess, answer))
+	}
+
+	return false
+}
@@ -0,0 +1,32 @@
+package main
+
+import "math/rand"
+
+// curatedOpeners are strong first guesses for --random-opener, each leaving
+// fewer than 85 of the answers possible on average.
+var curatedOpeners = []string{
+	"ALERT", "ALTER", "ARISE", "CARLE", "CARTE", "CRANE", "CRATE", "GRATE",
+	"IRATE", "LATER", "LEANT", "LEAST", "LIANE", "PRATE", "RAISE", "REAST",
+	"ROAST", "ROATE", "SALET", "SANER", "SLATE", "SOARE", "STALE", "STARE",
+	"TALER", "TALES", "TEARS", "TRACE", "TRADE", "TRIAL",
+}
+
+// randomOpener picks one of the curated openers, skipping any the player has
+// banned as an opener.
+func randomOpener(gamestats *GameStats) string {
+	choices := []string{}
+
+	for _, opener := range curatedOpeners {
+		if !gamestats.isBannedOpener(opener) {
+			choices = append(choices, opener)
+		}
+	}
+
+	if len(choices) == 0 {
+		return ""
+	}
+
+	rng := rand.New(rand.NewSource(gameSeed))
+
+	return choices[rng.Intn(len(choices))]
+}
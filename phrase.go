@@ -0,0 +1,200 @@
+package main
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"math/rand"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-tty"
+)
+
+// PhraseWords is how many words make up a phrase, each on its own board.
+const PhraseWords = 2
+
+//go:embed phrases.txt
+var rawPhrases string
+
+type PhraseStats struct {
+	Games int `json:"games"`
+	Wins  int `json:"wins"`
+}
+
+type PhraseCommand struct{}
+
+// phraseLine is the screen line of a guess on one of the stacked boards, with
+// a blank line between them.
+func phraseLine(board int, row int) int {
+	return board*(TotalGuesses+1) + row
+}
+
+func (cmd *PhraseCommand) Execute(_ []string) error {
+	gamestats := loadGameStats()
+	gamestats.applyTheme()
+
+	parseWordLists()
+
+	rand.Seed(time.Now().UnixNano())
+
+	phrases := loadPhrases()
+	answers := phrases[rand.Intn(len(phrases))]
+
+	resetGame()
+
+	fmt.Println("    Phrase (experimental)")
+
+	ty, err := tty.Open()
+	if err != nil {
+		return err
+	}
+
+	tyOpen := true
+	defer func() {
+		if tyOpen {
+			ty.Close()
+		}
+	}()
+
+	status := phraseLine(PhraseWords, 0) - 1
+	keys := status + 1
+
+	stat, err := newScreen(keys + KeyboardRows)
+	if err != nil {
+		return err
+	}
+	defer stat.Finish()
+
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, os.Interrupt)
+
+	go func() {
+		<-c
+
+		ty.Close()
+		stat.Finish()
+		fmt.Printf("\nThe phrase was %s\n", strings.Join(answers, " "))
+		os.Exit(0)
+	}()
+
+	for board := 0; board < PhraseWords; board++ {
+		for i := 0; i < TotalGuesses; i++ {
+			_, _ = stat.WriteString(phraseLine(board, i), blankRow(i))
+		}
+	}
+
+	printKeyboardAt(stat, keys)
+
+	solved := make([]bool, PhraseWords)
+	rows := make([]int, PhraseWords)
+	share := make([][]string, PhraseWords)
+	board := 0
+
+	for {
+		// guesses alternate between the boards until one of them is done
+		if solved[board] || rows[board] == TotalGuesses {
+			board = (board + 1) % PhraseWords
+		}
+
+		if solved[board] || rows[board] == TotalGuesses {
+			break
+		}
+
+		_, _ = stat.WriteString(status, fmt.Sprintf("Word %d of %d", board+1, PhraseWords))
+
+		guess, err := readGuess(ty, stat, phraseLine(board, rows[board]), mustBeWord)
+		if err != nil {
+			return err
+		}
+
+		hints := scoreGuess(guess, answers[board])
+		_, _ = stat.WriteString(phraseLine(board, rows[board]), colorGuess(guess, hints))
+
+		updateDoubleKeyboard(guess, hints, answers, solved)
+		printKeyboardAt(stat, keys)
+
+		share[board] = append(share[board], hintEmoji(hints))
+		solved[board] = guess == answers[board]
+		rows[board]++
+		board = (board + 1) % PhraseWords
+	}
+
+	stat.Finish()
+	ty.Close()
+	tyOpen = false
+
+	win := countTrue(solved) == PhraseWords
+	guesses := 0
+
+	for _, used := range rows {
+		guesses += used
+	}
+
+	gamestats.Phrase.Games++
+	if win {
+		gamestats.Phrase.Wins++
+	}
+
+	_ = gamestats.save()
+
+	if win {
+		fmt.Print("You win!\n\n")
+	} else {
+		fmt.Printf("\nThe phrase was %s\n\n", strings.Join(answers, " "))
+	}
+
+	fmt.Printf("Phrases solved: %d of %d\n", gamestats.Phrase.Wins, gamestats.Phrase.Games)
+
+	if !gamestats.useEmoji() {
+		return nil
+	}
+
+	turn := "X"
+	if win {
+		turn = fmt.Sprint(guesses)
+	}
+
+	fmt.Println()
+	fmt.Printf("Wordle Phrase %s/%d\n", turn, PhraseWords*TotalGuesses)
+
+	for _, grid := range share {
+		fmt.Println()
+
+		for _, line := range grid {
+			fmt.Println(gamestats.shareLine(line))
+		}
+	}
+
+	return nil
+}
+
+// loadPhrases reads the embedded phrases, one per line. Phrases with a word
+// that isn't a valid guess are skipped so they can't be unwinnable.
+func loadPhrases() [][]string {
+	phrases := [][]string{}
+
+	scanner := bufio.NewScanner(strings.NewReader(rawPhrases))
+	for scanner.Scan() {
+		words := strings.Fields(strings.ToUpper(scanner.Text()))
+		if len(words) != PhraseWords {
+			continue
+		}
+
+		valid := true
+
+		for _, w := range words {
+			if !isWord(w) {
+				valid = false
+			}
+		}
+
+		if valid {
+			phrases = append(phrases, words)
+		}
+	}
+
+	return phrases
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"bufio"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	mathrand "math/rand"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WordPlan is a run of random answers picked ahead of time with --preplan.
+// Only their hashes go in the plan file until each is played, so a streamer
+// can show the file at the start and prove afterwards that the words played
+// were the ones planned, not rerolled until an easy one came up.
+type WordPlan struct {
+	File   string   `json:"file"`
+	Words  []string `json:"words"`
+	Nonces []string `json:"nonces"`
+	Next   int      `json:"next"`
+}
+
+type VerifyPlanCommand struct {
+	Args struct {
+		File string `positional-arg-name:"file" description:"Plan file written by --preplan"`
+	} `positional-args:"yes"`
+}
+
+// newWordPlan picks the next n random answers and writes the plan file.
+func newWordPlan(n int, file string) (*WordPlan, error) {
+	path, err := filepath.Abs(file)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &WordPlan{File: path}
+
+	mathrand.Seed(gameSeed)
+
+	for i := 0; i < n; i++ {
+		raw := make([]byte, 8)
+		_, _ = rand.Read(raw)
+
+		plan.Words = append(plan.Words, wordList[mathrand.Intn(len(wordList))])
+		plan.Nonces = append(plan.Nonces, hex.EncodeToString(raw))
+	}
+
+	out := &strings.Builder{}
+	fmt.Fprintf(out, "# wordle plan of %d words, made %s\n", n, time.Now().Format("2006-01-02 15:04 MST"))
+	fmt.Fprintf(out, "# check it with: wordle verify-plan %s\n", filepath.Base(path))
+
+	for i := range plan.Words {
+		fmt.Fprintf(out, "%d %s\n", i+1, planCommitment(i+1, plan.Nonces[i], plan.Words[i]))
+	}
+
+	if err := os.WriteFile(path, []byte(out.String()), 0644); err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+// remaining is how many planned words haven't been played yet.
+func (plan *WordPlan) remaining() int {
+	if plan == nil {
+		return 0
+	}
+
+	return len(plan.Words) - plan.Next
+}
+
+// reveal adds a played word and its nonce to the plan file, so its hash can be
+// checked.
+func (plan *WordPlan) reveal(index int) {
+	file, err := os.OpenFile(plan.File, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: couldn't reveal the planned word: %v\n", err)
+		return
+	}
+	defer file.Close()
+
+	fmt.Fprintf(file, "reveal %d %s %s\n", index+1, plan.Nonces[index], plan.Words[index])
+
+	if plan.remaining() == 0 {
+		fmt.Printf("Every planned word has been played, check them with wordle verify-plan %s\n\n", plan.File)
+	}
+}
+
+// planCommitment is the hash of a planned word. The nonce keeps it from being
+// checked against every answer to spoil the word early.
+func planCommitment(number int, nonce string, word string) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%d %s %s", number, nonce, word)))
+
+	return hex.EncodeToString(sum[:])
+}
+
+func (cmd *VerifyPlanCommand) Execute(_ []string) error {
+	if cmd.Args.File == "" {
+		return errors.New("expected the plan file to check")
+	}
+
+	file, err := os.Open(cmd.Args.File)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	commitments := map[int]string{}
+	revealed := map[int]bool{}
+	bad := 0
+	n := 0
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		n++
+
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		if fields[0] == "reveal" && len(fields) == 4 {
+			number, err := strconv.Atoi(fields[1])
+			if err != nil || commitments[number] == "" {
+				return fmt.Errorf("%s:%d: reveal of a word that wasn't planned", cmd.Args.File, n)
+			}
+
+			word := strings.ToUpper(fields[3])
+			if planCommitment(number, fields[2], word) == commitments[number] {
+				fmt.Printf("%d %s matches\n", number, word)
+			} else {
+				fmt.Printf("%d %s DOESN'T MATCH\n", number, word)
+				bad++
+			}
+
+			revealed[number] = true
+
+			continue
+		}
+
+		number, err := strconv.Atoi(fields[0])
+		if err != nil || len(fields) != 2 {
+			return fmt.Errorf("%s:%d: expected a number and a hash", cmd.Args.File, n)
+		}
+
+		commitments[number] = strings.ToLower(fields[1])
+	}
+
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	if len(commitments)-len(revealed) > 0 {
+		fmt.Printf("%d of %d planned words not played yet\n", len(commitments)-len(revealed), len(commitments))
+	}
+
+	if bad > 0 {
+		return fmt.Errorf("%d of the words played don't match the plan", bad)
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package main
+
+import "sort"
+
+// How rare an answer is would best come from how often it's used, but no word
+// frequency corpus ships with the game, so it's judged the same way the
+// difficulty tiers are: by how unusual its letters are compared to the rest of
+// the answer list. It's a percentile of letters, not of use.
+var answerDifficulties []float64
+var answerCommonness map[rune]float64
+
+// answerRarity is the percentage of answers with more common letters than w.
+func answerRarity(w string) int {
+	if answerDifficulties == nil {
+		if wordList == nil {
+			parseWordLists()
+		}
+
+		answerCommonness = letterCommonness(wordList)
+		answerDifficulties = make([]float64, len(wordList))
+
+		for i, answer := range wordList {
+			answerDifficulties[i] = wordDifficulty(answer, answerCommonness)
+		}
+
+		sort.Float64s(answerDifficulties)
+	}
+
+	difficulty := wordDifficulty(w, answerCommonness)
+	easier := sort.SearchFloat64s(answerDifficulties, difficulty)
+
+	return easier * 100 / len(answerDifficulties)
+}
+
+// averageRarity averages the rarity of every answer played, it returns -1 when
+// there's nothing to average.
+func (gs *GameStats) averageRarity() int {
+	total := 0
+	games := 0
+
+	for _, game := range gs.History {
+		if game.Answer == "" {
+			continue
+		}
+
+		total += answerRarity(game.Answer)
+		games++
+	}
+
+	for _, summary := range gs.Compacted {
+		total += summary.Rarity
+		games += summary.RarityGames
+	}
+
+	if games == 0 {
+		return -1
+	}
+
+	return total / games
+}
@@ -0,0 +1,88 @@
+package main
+
+// DifficultyOpener is the first guess the bot always makes when rating an
+// answer, so ratings don't depend on anything but the answer.
+const DifficultyOpener = "SLATE"
+
+// DifficultyRecent is how many of the latest dailies stats average separately,
+// to show a hard week next to the long run.
+const DifficultyRecent = 7
+
+// botGuesses is how many guesses the expected strategy takes to find the
+// answer, picking from every valid word after opening with DifficultyOpener.
+func botGuesses(answer string, pool []string) int {
+	strategy := bucketStrategy(expectedScore)
+	state := &SolverState{Candidates: wordList, Pool: pool}
+	guess := DifficultyOpener
+
+	for n := 1; ; n++ {
+		if guess == answer || n >= TotalGuesses*2 {
+			return n
+		}
+
+		state.Guesses = append(state.Guesses, guess)
+		state.Candidates = remainingCandidates([]string{guess}, answer, state.Candidates)
+
+		guess = strategy.SuggestGuess(state)
+		if guess == "" {
+			return TotalGuesses * 2
+		}
+	}
+}
+
+// answerDifficulty rates from 0 to 100 how hard an answer is to find. Most of
+// it is how many guesses the bot needed, two or fewer counting as easy and six
+// or more as hard, the rest is how rare the answer's letters are.
+func answerDifficulty(answer string) int {
+	bot := float64(botGuesses(answer, (&GameStats{}).suggestionPool(SuggestFull))-2) / 4
+	if bot < 0 {
+		bot = 0
+	} else if bot > 1 {
+		bot = 1
+	}
+
+	return int(bot*70) + answerRarity(answer)*30/100
+}
+
+// averageDifficulty averages the difficulty of the dailies it was recorded
+// for, overall and over the latest DifficultyRecent. Both are -1 when there's
+// nothing to average.
+func (gs *GameStats) averageDifficulty() (overall int, recent int) {
+	total := 0
+	games := 0
+	recentTotal := 0
+
+	for i := len(gs.History) - 1; i >= 0; i-- {
+		difficulty := gs.History[i].Difficulty
+		if difficulty == nil {
+			continue
+		}
+
+		total += *difficulty
+		games++
+
+		if games <= DifficultyRecent {
+			recentTotal += *difficulty
+		}
+	}
+
+	recentGames := games
+
+	for _, summary := range gs.Compacted {
+		total += summary.Difficulty
+		games += summary.DifficultyGames
+	}
+
+	if games == 0 {
+		return -1, -1
+	}
+
+	switch {
+	case recentGames == 0:
+		return total / games, -1
+	case recentGames < DifficultyRecent:
+		return total / games, recentTotal / recentGames
+	}
+
+	return total / games, recentTotal / DifficultyRecent
+}
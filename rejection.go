@@ -0,0 +1,69 @@
+package main
+
+import "fmt"
+
+// Codes for why a guess was rejected. The reasons shown next to a guess are
+// for people, these are for bots and frontends that need to react to them.
+const (
+	RejectTooShort       = "TOO_SHORT"
+	RejectNotAWord       = "NOT_A_WORD"
+	RejectBannedOpener   = "BANNED_OPENER"
+	RejectHardModeGreen  = "HARD_MODE_GREEN"
+	RejectHardModeYellow = "HARD_MODE_YELLOW"
+	RejectRepeatedLetter = "REPEATED_LETTER"
+)
+
+// Rejection is why a guess wasn't accepted, as a code and as the reason shown
+// to the player, e.g. HARD_MODE_GREEN and "3rd letter must be A".
+type Rejection struct {
+	Code   string `json:"code"`
+	Reason string `json:"reason"`
+}
+
+// checkGuess runs a guess past every rule the current game has, in the order
+// they're reported, and returns the first one it breaks or nil.
+func (gs *GameStats) checkGuess(guess string) *Rejection {
+	if rejection := wordRejection(guess); rejection != nil {
+		return rejection
+	}
+
+	// self-imposed opener bans
+	if currentGuess == 0 && gs.isBannedOpener(guess) {
+		return &Rejection{RejectBannedOpener, "banned as an opener"}
+	}
+
+	if args.HardMode {
+		if rejection := hardModeViolation(guess); rejection != nil {
+			return rejection
+		}
+	}
+
+	// self-imposed letter coverage
+	if args.NoRepeats {
+		if rejection := noRepeatViolation(guess); rejection != nil {
+			return rejection
+		}
+	}
+
+	return nil
+}
+
+// wordRejection checks just that a guess is a word, for places with no other
+// rules like the chat bots, where a guess can be typed any length.
+func wordRejection(guess string) *Rejection {
+	if len(guess) < WordLength {
+		return &Rejection{RejectTooShort, "too short"}
+	}
+
+	if !isWord(guess) {
+		return &Rejection{RejectNotAWord, "must be a word"}
+	}
+
+	return nil
+}
+
+// String is the reason followed by the code, for replies read by people and
+// bots alike, e.g. "must be a word (NOT_A_WORD)".
+func (r *Rejection) String() string {
+	return fmt.Sprintf("%s (%s)", r.Reason, r.Code)
+}
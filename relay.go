@@ -0,0 +1,200 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-tty"
+)
+
+const (
+	RelayMinPlayers = 2
+	RelayMaxPlayers = 3
+)
+
+// RelayStats is a relay team's record. Teams are kept by their players'
+// names, so the same players in any order are the same team.
+type RelayStats struct {
+	Games int `json:"games"`
+	Wins  int `json:"wins"`
+}
+
+type RelayCommand struct {
+	Rounds int `long:"rounds" default:"1" description:"How many words to play, the first guess passes to the next player each round" value-name:"N"`
+
+	Args struct {
+		Players []string `positional-arg-name:"player" description:"Names of the 2 or 3 players, in the order they take turns"`
+	} `positional-args:"yes"`
+}
+
+// Contribution is what one player of a team game did.
+type Contribution struct {
+	Name    string
+	Guesses int
+	Greens  int // letters first placed by their guesses
+	Solves  int
+}
+
+func (cmd *RelayCommand) Execute(_ []string) error {
+	players := cmd.Args.Players
+	if len(players) < RelayMinPlayers || len(players) > RelayMaxPlayers {
+		return fmt.Errorf("a relay needs %d or %d players, e.g. wordle relay ALEX SAM", RelayMinPlayers, RelayMaxPlayers)
+	}
+
+	if cmd.Rounds < 1 {
+		return errors.New("--rounds must be at least 1")
+	}
+
+	gamestats := loadGameStats()
+	gamestats.applyTheme()
+
+	parseWordLists()
+
+	rand.Seed(time.Now().UnixNano())
+
+	team := relayTeam(players)
+
+	ty, err := tty.Open()
+	if err != nil {
+		return err
+	}
+	defer ty.Close()
+
+	session := newSession()
+	for _, name := range players {
+		session.Contributions = append(session.Contributions, &Contribution{Name: name})
+	}
+
+	var stat *Screen
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+
+	go func() {
+		<-interrupt
+
+		ty.Close()
+
+		if stat != nil {
+			stat.Finish()
+		}
+
+		fmt.Printf("\nThe word was %s\n\n", word)
+		session.print()
+		os.Exit(0)
+	}()
+
+	for round := 0; round < cmd.Rounds; round++ {
+		word = wordList[rand.Intn(len(wordList))]
+		resetGame()
+
+		fmt.Printf("    Relay, round %d of %d\n", round+1, cmd.Rounds)
+
+		stat, err = newScreen(TotalGuesses + 1 + KeyboardRows) // +1 for "status" line
+		if err != nil {
+			return err
+		}
+
+		// whoever opens moves along each round so everyone gets a turn at it
+		win, err := playRelayRound(ty, stat, gamestats, session.Contributions, round)
+
+		stat.Finish()
+
+		if err != nil {
+			return err
+		}
+
+		session.record(win, currentGuess+1)
+		gamestats.recordRelay(team, win)
+		_ = gamestats.save()
+
+		if win {
+			solver := session.Contributions[(round+currentGuess)%len(players)].Name
+			fmt.Printf("Team %s wins in %d! Solved by %s\n\n", team, currentGuess+1, solver)
+		} else {
+			fmt.Printf("\nThe word was %s\n\n", word)
+		}
+	}
+
+	session.print()
+
+	record := gamestats.Relay[team]
+	fmt.Printf("Team %s has won %d of %d relays\n", team, record.Wins, record.Games)
+
+	return nil
+}
+
+// playRelayRound plays one word, the players taking turns at the guesses
+// starting with the one whose turn it is this round.
+func playRelayRound(ty *tty.TTY, stat *Screen, gs *GameStats, players []*Contribution, round int) (win bool, err error) {
+	for i := 0; i < TotalGuesses; i++ {
+		_, _ = stat.WriteString(i, blankRow(i))
+	}
+
+	printKeyboardAt(stat, TotalGuesses+1)
+
+	check := func(guess string) string {
+		if rejection := gs.checkGuess(guess); rejection != nil {
+			return rejection.Reason
+		}
+
+		return ""
+	}
+
+	for currentGuess = 0; currentGuess < TotalGuesses; currentGuess++ {
+		player := players[(round+currentGuess)%len(players)]
+		_, _ = stat.WriteString(TotalGuesses, player.Name+"'s turn")
+
+		guess, err := readGuess(ty, stat, currentGuess, check)
+		if err != nil {
+			return false, err
+		}
+
+		before := countTrue(discovered)
+
+		_, _ = stat.WriteString(currentGuess, formatGuess(guess, true))
+		printKeyboardAt(stat, TotalGuesses+1)
+
+		guessHistory = append(guessHistory, guess)
+		player.Guesses++
+		player.Greens += countTrue(discovered) - before
+
+		if guess == word {
+			player.Solves++
+			return true, nil
+		}
+	}
+
+	currentGuess--
+
+	return false, nil
+}
+
+// relayTeam names a team after its players.
+func relayTeam(players []string) string {
+	names := append([]string{}, players...)
+	sort.Strings(names)
+
+	return strings.Join(names, " & ")
+}
+
+func (gs *GameStats) recordRelay(team string, win bool) {
+	if gs.Relay == nil {
+		gs.Relay = map[string]RelayStats{}
+	}
+
+	record := gs.Relay[team]
+	record.Games++
+
+	if win {
+		record.Wins++
+	}
+
+	gs.Relay[team] = record
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+	"unicode"
+
+	"github.com/mattn/go-tty"
+)
+
+type ReplayCommand struct {
+	Game int `short:"g" long:"game" default:"1" description:"Which game of the day to replay"`
+
+	Args struct {
+		Date string `positional-arg-name:"date" description:"Day the game was played, as YYYY-MM-DD"`
+	} `positional-args:"yes" required:"yes"`
+}
+
+func (cmd *ReplayCommand) Execute(_ []string) error {
+	day, err := time.ParseInLocation("2006-01-02", cmd.Args.Date, time.Local)
+	if err != nil {
+		return fmt.Errorf("invalid date %q, expected YYYY-MM-DD", cmd.Args.Date)
+	}
+
+	gamestats := loadGameStats()
+	gamestats.applyTheme()
+
+	record, err := gamestats.findGame(day, cmd.Game)
+	if err != nil {
+		return err
+	}
+
+	replayGame(record)
+
+	return nil
+}
+
+// findGame looks up the n-th game played on a day, counting from 1.
+func (gs *GameStats) findGame(day time.Time, n int) (*GameRecord, error) {
+	found := 0
+
+	for i := range gs.History {
+		if gs.History[i].Date.Local().Format("2006-01-02") != day.Format("2006-01-02") {
+			continue
+		}
+
+		found++
+		if found == n {
+			return &gs.History[i], nil
+		}
+	}
+
+	if found == 0 {
+		return nil, fmt.Errorf("no games found on %s", day.Format("2006-01-02"))
+	}
+
+	return nil, fmt.Errorf("only %d game(s) found on %s", found, day.Format("2006-01-02"))
+}
+
+// replayGame reveals a recorded game's guesses one at a time, advancing each
+// time the spacebar is pressed.
+func replayGame(record *GameRecord) {
+	word = record.Answer
+	initKeyboard()
+
+	if record.HardMode {
+		fmt.Println("     Hard Mode")
+	}
+
+	ty, err := tty.Open()
+	if err != nil {
+		panic(err)
+	}
+	defer ty.Close()
+
+	stat, err := newScreen(TotalGuesses + 1 + KeyboardRows + PanelLines) // +1 for "status" line
+	if err != nil {
+		panic(err)
+	}
+	defer stat.Finish()
+
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, os.Interrupt)
+
+	go func() {
+		<-c
+
+		ty.Close()
+		stat.Finish()
+		os.Exit(0)
+	}()
+
+	for i := 0; i < TotalGuesses; i++ {
+		_, _ = stat.WriteString(boardLine(i), "     _ _ _ _ _")
+	}
+
+	printKeyboard(stat)
+
+	for i, guess := range record.Guesses {
+		if i >= TotalGuesses {
+			break
+		}
+
+		_, _ = stat.WriteString(statusLine(), "(space to reveal the next guess, q to quit)")
+
+		for {
+			pressed, err := ty.ReadRune()
+			if err != nil {
+				panic(err)
+			}
+
+			if unicode.ToUpper(pressed) == 'Q' {
+				return
+			}
+
+			if pressed == ' ' {
+				break
+			}
+		}
+
+		_, _ = stat.WriteString(boardLine(i), formatGuess(guess, true))
+		printKeyboard(stat)
+	}
+
+	if record.Won {
+		_, _ = stat.WriteString(statusLine(), fmt.Sprintf("Solved in %d", len(record.Guesses)))
+	} else {
+		_, _ = stat.WriteString(statusLine(), fmt.Sprintf("The word was %s", record.Answer))
+	}
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"strings"
+)
+
+// Daily rotations, the daily_rotation config.
+const (
+	RotationInOrder  = "in_order"
+	RotationShuffled = "shuffled"
+)
+
+// DaysPerYear is how short an answer list can be before the daily repeats
+// within a year.
+const DaysPerYear = 365
+
+// shuffledCycles holds the shuffled order of the answers for each time
+// through the list, worked out once.
+var shuffledCycles [][]int
+
+// dailyIndex is where in the answer list the daily for a day is.
+func (gs *GameStats) dailyIndex(day int) int {
+	if gs.DailyRotation != RotationShuffled {
+		return day % len(wordList)
+	}
+
+	cycle := shuffledCycle(day / len(wordList))
+
+	return cycle[day%len(wordList)]
+}
+
+// shuffledCycle is the order of the answers the nth time through the list in
+// the shuffled rotation. Shuffles are seeded from the list itself, so everyone
+// with the same list gets the same dailies.
+//
+// Every cycle after the first keeps the words that came in the first half of
+// the cycle before in its first half, and the second half in the second, and
+// only shuffles within each half. That way a word is never the daily again
+// sooner than half the list later, where a fresh shuffle could put the last
+// word of one cycle first in the next.
+func shuffledCycle(n int) []int {
+	for len(shuffledCycles) <= n {
+		rng := rand.New(rand.NewSource(wordListSeed() + int64(len(shuffledCycles))))
+
+		if len(shuffledCycles) == 0 {
+			shuffledCycles = append(shuffledCycles, rng.Perm(len(wordList)))
+			continue
+		}
+
+		order := append([]int{}, shuffledCycles[len(shuffledCycles)-1]...)
+		half := len(order) / 2
+
+		for _, part := range [][]int{order[:half], order[half:]} {
+			rng.Shuffle(len(part), func(i, j int) {
+				part[i], part[j] = part[j], part[i]
+			})
+		}
+
+		shuffledCycles = append(shuffledCycles, order)
+	}
+
+	return shuffledCycles[n]
+}
+
+// wordListSeed hashes the answer list.
+func wordListSeed() int64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(strings.Join(wordList, "\n")))
+
+	return int64(h.Sum64())
+}
+
+// rotationWarning warns when the answer list is short enough that the daily
+// repeats within a year, suggesting the shuffled rotation if it's not on.
+func rotationWarning(answers int, rotation string) string {
+	if answers >= DaysPerYear {
+		return ""
+	}
+
+	if rotation == RotationShuffled {
+		return fmt.Sprintf("there are only %d answers, dailies repeat every %d days in a shuffled order", answers, answers)
+	}
+
+	return fmt.Sprintf("there are only %d answers, so the dailies repeat in the same order every %d days, "+
+		"set daily_rotation to %q in the config to shuffle each time through the list", answers, answers, RotationShuffled)
+}
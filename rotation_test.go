@@ -0,0 +1,87 @@
+package main
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestDailyIndex(t *testing.T) {
+	parseWordLists()
+
+	n := len(wordList)
+
+	tests := []struct {
+		name     string
+		rotation string
+	}{
+		{"in order", RotationInOrder},
+		{"unset", ""},
+		{"shuffled", RotationShuffled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gs := GameStats{DailyRotation: tt.rotation}
+
+			for cycle := 0; cycle < 3; cycle++ {
+				seen := make([]bool, n)
+
+				for day := cycle * n; day < (cycle+1)*n; day++ {
+					i := gs.dailyIndex(day)
+
+					if tt.rotation != RotationShuffled && i != day%n {
+						t.Fatalf("dailyIndex(%d) = %d, want %d", day, i, day%n)
+					}
+
+					if seen[i] {
+						t.Fatalf("answer %d comes up twice in cycle %d", i, cycle)
+					}
+
+					seen[i] = true
+				}
+			}
+		})
+	}
+}
+
+func TestShuffledCycleKeepsHalves(t *testing.T) {
+	parseWordLists()
+
+	half := len(wordList) / 2
+
+	for n := 1; n < 4; n++ {
+		before, after := shuffledCycle(n-1), shuffledCycle(n)
+
+		for _, part := range [][2]int{{0, half}, {half, len(wordList)}} {
+			was := append([]int{}, before[part[0]:part[1]]...)
+			is := append([]int{}, after[part[0]:part[1]]...)
+			sort.Ints(was)
+			sort.Ints(is)
+
+			for i := range was {
+				if was[i] != is[i] {
+					t.Fatalf("cycle %d moved answers between halves of cycle %d", n, n-1)
+				}
+			}
+		}
+	}
+}
+
+func TestRotationWarning(t *testing.T) {
+	tests := []struct {
+		answers  int
+		rotation string
+		warn     bool
+	}{
+		{DaysPerYear, RotationInOrder, false},
+		{2315, RotationShuffled, false},
+		{DaysPerYear - 1, RotationInOrder, true},
+		{100, RotationShuffled, true},
+	}
+
+	for _, tt := range tests {
+		if got := rotationWarning(tt.answers, tt.rotation); (got != "") != tt.warn {
+			t.Errorf("rotationWarning(%d, %q) = %q, want a warning %v", tt.answers, tt.rotation, got, tt.warn)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+type ScoreCommand struct {
+	Answer string `long:"answer" required:"yes" description:"The hidden word"`
+	Guess  string `long:"guess" required:"yes" description:"The guess to score against it"`
+}
+
+func (cmd *ScoreCommand) Execute(_ []string) error {
+	// a one-off score never touches the stats file, so the theme comes from
+	// --theme or the terminal
+	(&GameStats{}).applyTheme()
+
+	answer := strings.ToUpper(strings.TrimSpace(cmd.Answer))
+	guess := strings.ToUpper(strings.TrimSpace(cmd.Guess))
+
+	for _, w := range []string{answer, guess} {
+		if !isFiveLetters(w) {
+			return fmt.Errorf("%q isn't a %d letter word", w, WordLength)
+		}
+	}
+
+	hints := scoreGuess(guess, answer)
+	slots := make([]string, WordLength)
+
+	for i, hint := range hints {
+		slots[i] = tileColorFns[hint](string(guess[i]))
+	}
+
+	fmt.Println(strings.Join(slots, " "))
+	fmt.Println(hintEmoji(hints))
+
+	if explanation := explainDuplicates(guess, answer, hints); explanation != "" {
+		fmt.Println(explanation)
+	}
+
+	return nil
+}
@@ -0,0 +1,320 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coreyog/statux"
+	"golang.org/x/term"
+)
+
+// Screen wraps statux and remembers what's on every line so the whole board
+// can be drawn again from scratch, e.g. after the terminal was handed back
+// from a suspended process.
+//
+// statux only makes room for the lines and gives them back when finished, the
+// lines are drawn here instead so a frame's worth of changes goes out in a
+// single write. Between Begin and Flush, writes are only remembered. Outside
+// of a frame, like the blitz clock ticking, each write is its own frame.
+// Either way lines that haven't changed aren't sent again and frames are
+// spaced out to --fps, which keeps slow SSH links from flickering.
+type Screen struct {
+	stat  *statux.Statux
+	lines []string
+	mutex sync.Mutex
+
+	// drawn is what's on the terminal, cursor is the line the cursor is on
+	drawn  []string
+	cursor int
+	width  int
+
+	// top is the terminal row the first line is on, counted from 1, or 0
+	// when it isn't known
+	top int
+
+	inFrame   bool
+	lastFlush time.Time
+	pending   *time.Timer
+}
+
+func newScreen(count int) (*Screen, error) {
+	stat, err := statux.New(count)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Screen{
+		stat:  stat,
+		lines: make([]string, count),
+		drawn: make([]string, count),
+		width: terminalWidth(),
+	}, nil
+}
+
+func (s *Screen) WriteString(index int, str string) (n int, err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if index < 0 || index >= len(s.lines) {
+		return 0, fmt.Errorf("invalid index: %d", index)
+	}
+
+	s.lines[index] = str
+
+	if !s.inFrame {
+		s.flush()
+	}
+
+	return len(str), nil
+}
+
+// Line is what was last written to a line.
+func (s *Screen) Line(index int) string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if index < 0 || index >= len(s.lines) {
+		return ""
+	}
+
+	return s.lines[index]
+}
+
+// Begin starts a frame, holding writes back until Flush.
+func (s *Screen) Begin() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.inFrame = true
+}
+
+// Flush ends the frame and draws everything written during it.
+func (s *Screen) Flush() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.inFrame = false
+	s.flush()
+}
+
+// flush draws the changed lines now, or once enough time has passed since
+// the last frame.
+func (s *Screen) flush() {
+	if s.pending != nil {
+		return
+	}
+
+	wait := frameInterval() - time.Since(s.lastFlush)
+	if wait <= 0 {
+		s.draw()
+		return
+	}
+
+	s.pending = time.AfterFunc(wait, func() {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+
+		s.pending = nil
+
+		if !s.inFrame {
+			s.draw()
+		}
+	})
+}
+
+// draw writes out every line that differs from what's on the terminal.
+func (s *Screen) draw() {
+	if s.stat.IsFinished() {
+		return
+	}
+
+	var frame strings.Builder
+
+	for i, line := range s.lines {
+		if line == s.drawn[i] {
+			continue
+		}
+
+		s.moveTo(&frame, i)
+		frame.WriteString(fitLine(line, s.width))
+
+		s.drawn[i] = line
+	}
+
+	if frame.Len() == 0 {
+		return
+	}
+
+	_, _ = os.Stdout.WriteString(frame.String())
+	s.lastFlush = time.Now()
+}
+
+// moveTo moves the cursor to the start of a line.
+func (s *Screen) moveTo(frame *strings.Builder, index int) {
+	if index < s.cursor {
+		fmt.Fprintf(frame, "\033[%dA", s.cursor-index)
+	} else if index > s.cursor {
+		fmt.Fprintf(frame, "\033[%dB", index-s.cursor)
+	}
+
+	fmt.Fprintf(frame, "\033[%dD", s.width)
+
+	s.cursor = index
+}
+
+// fitLine pads a line to clear what was there before, or cuts it short with a
+// $ if it's too wide, the same as statux. Widths are counted in columns, not
+// bytes, so colors and double width characters don't throw the padding off
+// or wrap the line, which would knock every line below it out of place.
+func fitLine(str string, width int) string {
+	str = strings.ReplaceAll(str, "\n", " ")
+	used := displayWidth(str)
+
+	if used > width {
+		return truncateWidth(str, width-1) + "$"
+	}
+
+	if used < width {
+		str += strings.Repeat(" ", width-used-1)
+	}
+
+	return str
+}
+
+// frameInterval is the shortest time between frames for --fps, or 0 for no
+// limit.
+func frameInterval() time.Duration {
+	if args.FPS <= 0 {
+		return 0
+	}
+
+	return time.Second / time.Duration(args.FPS)
+}
+
+func terminalWidth() int {
+	width, _, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil || width <= 0 {
+		return 80
+	}
+
+	return width
+}
+
+// Redraw starts a fresh block of lines below the cursor and writes everything
+// back out.
+func (s *Screen) Redraw() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	stat, err := statux.New(len(s.lines))
+	if err != nil {
+		return err
+	}
+
+	s.stat = stat
+	s.cursor = 0
+	s.width = terminalWidth()
+	s.top = 0
+
+	for i := range s.drawn {
+		s.drawn[i] = ""
+	}
+
+	s.draw()
+
+	return nil
+}
+
+// CursorLine is the line the cursor is on.
+func (s *Screen) CursorLine() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.cursor
+}
+
+// Top is the terminal row the first line is on, counted from 1, or 0 when it
+// isn't known. It's forgotten on Redraw.
+func (s *Screen) Top() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.top
+}
+
+// SetTop works out which terminal row the first line is on from the row the
+// cursor is on.
+func (s *Screen) SetTop(cursorRow int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.top = cursorRow - s.cursor
+}
+
+// Prompt draws a prompt on a line and leaves the cursor showing after it, for
+// the terminal to echo a line typed there.
+func (s *Screen) Prompt(index int, prompt string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.pending != nil {
+		s.pending.Stop()
+		s.pending = nil
+	}
+
+	s.lines[index] = prompt
+	s.draw()
+
+	var frame strings.Builder
+
+	s.moveTo(&frame, index)
+	frame.WriteString(fitLine("", s.width))
+	fmt.Fprintf(&frame, "\033[%dD%s\033[?25h", s.width, prompt)
+
+	s.drawn[index] = prompt
+
+	_, _ = os.Stdout.WriteString(frame.String())
+}
+
+// Entered accounts for the line typed after a Prompt: the Enter that ended it
+// left the cursor on the line below, and what was typed is still on the
+// prompt line until it's drawn over.
+func (s *Screen) Entered(index int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	_, _ = os.Stdout.WriteString("\033[?25l")
+
+	s.cursor = index + 1
+	s.drawn[index] = ""
+}
+
+func (s *Screen) Finish() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.pending != nil {
+		s.pending.Stop()
+		s.pending = nil
+	}
+
+	s.draw()
+
+	// statux still thinks the cursor is on the first line
+	if s.cursor > 0 && !s.stat.IsFinished() {
+		fmt.Printf("\033[%dA", s.cursor)
+		s.cursor = 0
+	}
+
+	s.stat.Finish()
+}
+
+func (s *Screen) IsFinished() bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.stat.IsFinished()
+}
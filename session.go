@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Session tallies the games played since the process started, separate from
+// the lifetime stats, for modes that play more than one game per run.
+type Session struct {
+	Started time.Time
+	Games   int
+	Wins    int
+	Guesses int // guesses spent on wins, for the average
+
+	// Contributions is what each player did, for modes with more than one
+	Contributions []*Contribution
+}
+
+func newSession() *Session {
+	return &Session{Started: time.Now()}
+}
+
+// record adds a finished game to the session.
+func (s *Session) record(win bool, guesses int) {
+	s.Games++
+
+	if win {
+		s.Wins++
+		s.Guesses += guesses
+	}
+}
+
+// print shows the session summary. Nothing is printed for a single game since
+// the regular end of game output already covers it, unless there are players
+// to credit.
+func (s *Session) print() {
+	if s.Games < 2 && len(s.Contributions) == 0 {
+		return
+	}
+
+	fmt.Print("This Session\n\n")
+	fmt.Printf("          Games: %d\n", s.Games)
+	fmt.Printf("           Wins: %d\n", s.Wins)
+
+	if s.Wins > 0 {
+		fmt.Printf("Average Guesses: %.2f\n", float64(s.Guesses)/float64(s.Wins))
+	}
+
+	fmt.Printf("           Time: %s\n", formatClock(time.Since(s.Started)))
+	fmt.Println()
+
+	if len(s.Contributions) == 0 {
+		return
+	}
+
+	width := len("Player")
+	for _, player := range s.Contributions {
+		if displayWidth(player.Name) > width {
+			width = displayWidth(player.Name)
+		}
+	}
+
+	fmt.Printf("%-*s  %7s  %6s  %6s\n", width, "Player", "Guesses", "Greens", "Solves")
+
+	for _, player := range s.Contributions {
+		fmt.Printf("%s%s  %7d  %6d  %6d\n", player.Name, strings.Repeat(" ", width-displayWidth(player.Name)),
+			player.Guesses, player.Greens, player.Solves)
+	}
+
+	fmt.Println()
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// How much of a game the share block gives away.
+const (
+	// ShareFull shows everything: group, puzzle number, hard mode, the grid,
+	// and any timing.
+	ShareFull = "full"
+	// ShareMinimal shows just the puzzle number and score.
+	ShareMinimal = "minimal"
+	// ShareAnonymous shows the score and grid without saying which puzzle.
+	ShareAnonymous = "anonymous"
+)
+
+// shareLevel picks the share privacy level, preferring --share-level over the
+// config.
+func (gs *GameStats) shareLevel() string {
+	level := gs.ShareLevel
+	if args.ShareLevel != "" {
+		level = args.ShareLevel
+	}
+
+	switch level {
+	case ShareMinimal, ShareAnonymous:
+		return level
+	default:
+		return ShareFull
+	}
+}
+
+// Share block formats, the share_format config and --share-format.
+const (
+	ShareFormatEmoji = "emoji"
+	// ShareFormatText draws the grid with plain characters, for places emoji
+	// get mangled like email, IRC, and commit messages.
+	ShareFormatText = "text"
+)
+
+// shareTextReplacer swaps the emoji of a share block for plain characters: #
+// for the right spot, + for somewhere else, and . for not in the word.
+var shareTextReplacer = strings.NewReplacer(
+	string(EmojiLocated), "#",
+	string(EmojiSomewhere), "+",
+	string(EmojiNotInWord), ".",
+	"⬜", "-",
+	"⏱️ ", "",
+	"🔥 ", "",
+	"🏆 ", "",
+)
+
+// shareFormat picks the share block format, preferring --share-format over
+// the config and emoji without either. The share block is only printed when
+// useEmoji says so, the format just picks how it's drawn.
+func (gs *GameStats) shareFormat() string {
+	format := gs.ShareFormat
+	if args.ShareFormat != "" {
+		format = args.ShareFormat
+	}
+
+	if format == ShareFormatText {
+		return ShareFormatText
+	}
+
+	return ShareFormatEmoji
+}
+
+// shareLine puts a line of the share block in the share format.
+func (gs *GameStats) shareLine(line string) string {
+	if gs.shareFormat() == ShareFormatText {
+		return shareTextReplacer.Replace(line)
+	}
+
+	return line
+}
+
+// keyboardSummary counts how many keys ended up each color, for the share
+// block, e.g. "Keys: 9⬛ 2🟨 5🟩".
+func keyboardSummary() string {
+	counts := map[KeyHint]int{}
+	for _, hint := range keyboard {
+		counts[hint]++
+	}
+
+	return fmt.Sprintf("Keys: %d%c %d%c %d%c", counts[KeyHintNotInWord], EmojiNotInWord, counts[KeyHintSomewhere], EmojiSomewhere, counts[KeyHintLocated], EmojiLocated)
+}
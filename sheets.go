@@ -0,0 +1,163 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// PDFPageLines is how many lines of text fit on a printed letter page.
+const PDFPageLines = 40
+
+type PrintCommand struct {
+	Days int    `long:"days" default:"7" description:"How many days of dailies to print, starting today"`
+	Out  string `long:"out" default:"-" description:"File to write, a .pdf extension makes a PDF and anything else plain text, - for stdout"`
+}
+
+func (cmd *PrintCommand) Execute(_ []string) error {
+	if cmd.Days < 1 {
+		return fmt.Errorf("--days must be at least 1")
+	}
+
+	gamestats := loadGameStats()
+
+	parseWordLists()
+
+	today := int(time.Since(dailyEpoch).Hours() / 24)
+	pages := [][]string{}
+	key := []string{"Answer Key", "", "Answers are written backwards so a glance doesn't spoil them.", ""}
+
+	for d := 0; d < cmd.Days; d++ {
+		day := today + d
+
+		index := gamestats.dailyIndex(day)
+		if args.Group != "" {
+			index = groupWordIndex(args.Group, day)
+		}
+
+		title := fmt.Sprintf("Wordle %d", day+gamestats.PuzzleNumberOffset)
+		if args.Group != "" {
+			title = fmt.Sprintf("Wordle %s %d", args.Group, day+gamestats.PuzzleNumberOffset)
+		}
+
+		date := dailyEpoch.AddDate(0, 0, day).Format("Mon Jan 2, 2006")
+		pages = append(pages, puzzleSheet(title, date))
+		key = append(key, fmt.Sprintf("%-20s %s", title, reverseWord(wordList[index])))
+	}
+
+	pages = append(pages, key)
+
+	var out []byte
+	if strings.EqualFold(filepath.Ext(cmd.Out), ".pdf") {
+		out = renderPDF(pages)
+	} else {
+		for i, page := range pages {
+			if i > 0 {
+				out = append(out, '\f', '\n') // form feed starts a new printed page
+			}
+
+			out = append(out, strings.Join(page, "\n")+"\n"...)
+		}
+	}
+
+	if cmd.Out == "-" {
+		_, err := os.Stdout.Write(out)
+		return err
+	}
+
+	return ioutil.WriteFile(cmd.Out, out, 0644)
+}
+
+// puzzleSheet lays out a blank board for one day, with a keyboard to cross
+// letters off of.
+func puzzleSheet(title string, date string) []string {
+	lines := []string{title, date, ""}
+
+	for i := 0; i < TotalGuesses; i++ {
+		lines = append(lines, "    +---+---+---+---+---+", "    |   |   |   |   |   |")
+	}
+
+	lines = append(lines, "    +---+---+---+---+---+", "")
+
+	for i, row := range []string{"QWERTYUIOP", "ASDFGHJKL", "ZXCVBNM"} {
+		lines = append(lines, strings.Repeat(" ", i+4)+strings.Join(strings.Split(row, ""), " "))
+	}
+
+	return lines
+}
+
+func reverseWord(w string) string {
+	reversed := []byte(w)
+	for i, j := 0, len(reversed)-1; i < j; i, j = i+1, j-1 {
+		reversed[i], reversed[j] = reversed[j], reversed[i]
+	}
+
+	return string(reversed)
+}
+
+// renderPDF writes pages of plain text as a bare bones PDF, one page per
+// sheet in a monospace font so the grids line up.
+func renderPDF(sheets [][]string) []byte {
+	// long sheets, like the answer key for a long trip, run onto more pages
+	pages := [][]string{}
+
+	for _, sheet := range sheets {
+		for len(sheet) > PDFPageLines {
+			pages = append(pages, sheet[:PDFPageLines])
+			sheet = sheet[PDFPageLines:]
+		}
+
+		pages = append(pages, sheet)
+	}
+
+	buf := &bytes.Buffer{}
+	offsets := []int{}
+
+	object := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	buf.WriteString("%PDF-1.4\n")
+
+	// objects 1 and 2 are the catalog and page tree, 3 is the font, then a
+	// page and its contents for each sheet
+	kids := []string{}
+	for i := range pages {
+		kids = append(kids, fmt.Sprintf("%d 0 R", 4+2*i))
+	}
+
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Courier >>")
+
+	for i, page := range pages {
+		content := &bytes.Buffer{}
+		content.WriteString("BT /F1 14 Tf 16 TL 72 720 Td\n")
+
+		for _, line := range page {
+			escaped := strings.NewReplacer(`\`, `\\`, "(", `\(`, ")", `\)`).Replace(line)
+			fmt.Fprintf(content, "(%s) Tj T*\n", escaped)
+		}
+
+		content.WriteString("ET")
+
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>", 5+2*i))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", content.Len(), content.String()))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+
+	for _, offset := range offsets {
+		fmt.Fprintf(buf, "%010d 00000 n \n", offset)
+	}
+
+	fmt.Fprintf(buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return buf.Bytes()
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"bufio"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ShareSignaturePrefix starts the line added to signed share blocks.
+const ShareSignaturePrefix = "Signed"
+
+type VerifyCommand struct {
+	Key string `long:"key" description:"Public key of whoever signed the block, from their wordle share-key (defaults to your own)"`
+}
+
+func (cmd *VerifyCommand) Execute(blocks []string) error {
+	key := cmd.Key
+	if key == "" {
+		own, err := loadShareKey(false)
+		if err != nil {
+			return fmt.Errorf("pass the signer's public key with --key: %w", err)
+		}
+
+		key = encodeShareKey(own.Public().(ed25519.PublicKey))
+	}
+
+	public, err := decodeShareKey(key)
+	if err != nil {
+		return err
+	}
+
+	text := strings.Join(blocks, "\n")
+	if len(blocks) == 0 {
+		raw := []string{}
+
+		scanner := bufio.NewScanner(os.Stdin)
+		for scanner.Scan() {
+			raw = append(raw, scanner.Text())
+		}
+
+		text = strings.Join(raw, "\n")
+	}
+
+	seconds, err := verifyShare(public, text)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Signature is valid, played in %s\n", formatClock(time.Duration(seconds)*time.Second))
+
+	return nil
+}
+
+type ShareKeyCommand struct{}
+
+func (cmd *ShareKeyCommand) Execute(_ []string) error {
+	key, err := loadShareKey(true)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(encodeShareKey(key.Public().(ed25519.PublicKey)))
+
+	return nil
+}
+
+// shareKeyPath is where the private key share blocks are signed with is kept,
+// next to the stats file but in its own file only the player can read.
+func shareKeyPath() (string, error) {
+	savePath, _, err := statsPath()
+	if err != nil {
+		return "", err
+	}
+
+	return savePath + ".key", nil
+}
+
+// loadShareKey reads the signing key, making one the first time it's needed
+// if create is set.
+func loadShareKey(create bool) (ed25519.PrivateKey, error) {
+	path, err := shareKeyPath()
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) && create {
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+
+		err = ioutil.WriteFile(path, []byte(hex.EncodeToString(key.Seed())+"\n"), 0600)
+		if err != nil {
+			return nil, err
+		}
+
+		return key, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	seed, err := hex.DecodeString(strings.TrimSpace(string(raw)))
+	if err != nil || len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("%s isn't a share signing key", path)
+	}
+
+	return ed25519.NewKeyFromSeed(seed), nil
+}
+
+// encodeShareKey turns a public key into text short enough to paste.
+func encodeShareKey(key ed25519.PublicKey) string {
+	return base64.RawURLEncoding.EncodeToString(key)
+}
+
+// decodeShareKey reads a public key printed by encodeShareKey.
+func decodeShareKey(text string) (ed25519.PublicKey, error) {
+	key, err := base64.RawURLEncoding.DecodeString(strings.TrimSpace(text))
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("%q isn't a share public key", text)
+	}
+
+	return ed25519.PublicKey(key), nil
+}
+
+// shareMessage is what's signed for a share block: its header and rows, which
+// include any timing annotations, and the total seconds played.
+func shareMessage(block []string, seconds int) []byte {
+	var b strings.Builder
+
+	for _, line := range block {
+		b.WriteString(strings.Join(strings.Fields(line), " ") + "\n")
+	}
+
+	b.WriteString(strconv.Itoa(seconds))
+
+	return []byte(b.String())
+}
+
+// signShare computes the signature of a share block and the total seconds
+// played.
+func signShare(key ed25519.PrivateKey, block []string, seconds int) string {
+	return base64.RawURLEncoding.EncodeToString(ed25519.Sign(key, shareMessage(block, seconds)))
+}
+
+// verifyShare checks the signature line of a pasted share block. Blank lines
+// and surrounding whitespace are ignored since chat apps tend to mangle them.
+func verifyShare(key ed25519.PublicKey, text string) (seconds int, err error) {
+	block := []string{}
+
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		if !strings.HasPrefix(line, ShareSignaturePrefix+" ") {
+			block = append(block, line)
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 3 || !strings.HasSuffix(fields[1], "s") {
+			return 0, fmt.Errorf("malformed signature line %q", line)
+		}
+
+		seconds, err = strconv.Atoi(strings.TrimSuffix(fields[1], "s"))
+		if err != nil {
+			return 0, fmt.Errorf("malformed signature line %q", line)
+		}
+
+		if len(block) == 0 {
+			return 0, errors.New("no share block before the signature")
+		}
+
+		signature, err := base64.RawURLEncoding.DecodeString(fields[2])
+		if err != nil || !ed25519.Verify(key, shareMessage(block, seconds), signature) {
+			return 0, errors.New("signature doesn't match, the block was changed or signed with a different key")
+		}
+
+		return seconds, nil
+	}
+
+	return 0, errors.New("no signature line found")
+}
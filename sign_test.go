@@ -0,0 +1,79 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestVerifyShare(t *testing.T) {
+	key := ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize))
+	other := ed25519.NewKeyFromSeed([]byte(strings.Repeat("x", ed25519.SeedSize)))
+
+	block := []string{"Wordle 1000 3/6*", "⬛🟨⬛⬛⬛ 0:12", "⬛🟩🟨⬛⬛ 0:31", "🟩🟩🟩🟩🟩 0:08"}
+	signed := strings.Join(block, "\n") + fmt.Sprintf("\n%s 51s %s", ShareSignaturePrefix, signShare(key, block, 51))
+
+	tests := []struct {
+		name    string
+		key     ed25519.PublicKey
+		text    string
+		seconds int
+		err     bool
+	}{
+		{"as signed", key.Public().(ed25519.PublicKey), signed, 51, false},
+		{"mangled by chat", key.Public().(ed25519.PublicKey), "  " + strings.ReplaceAll(signed, "\n", "\n\n  ") + "\n", 51, false},
+		{"spaces squeezed", key.Public().(ed25519.PublicKey), strings.Replace(signed, "⬛⬛ 0:12", "⬛⬛   0:12", 1), 51, false},
+		{"someone else's key", other.Public().(ed25519.PublicKey), signed, 0, true},
+		{"row changed", key.Public().(ed25519.PublicKey), strings.Replace(signed, "⬛🟩🟨", "🟩🟩🟨", 1), 0, true},
+		{"time changed", key.Public().(ed25519.PublicKey), strings.Replace(signed, " 51s ", " 41s ", 1), 0, true},
+		{"header changed", key.Public().(ed25519.PublicKey), strings.Replace(signed, "3/6*", "2/6*", 1), 0, true},
+		{"not signed", key.Public().(ed25519.PublicKey), strings.Join(block, "\n"), 0, true},
+		{"signature alone", key.Public().(ed25519.PublicKey), signed[strings.Index(signed, ShareSignaturePrefix):], 0, true},
+		{"signature cut short", key.Public().(ed25519.PublicKey), signed[:len(signed)-4], 0, true},
+		{"malformed", key.Public().(ed25519.PublicKey), strings.Join(block, "\n") + "\n" + ShareSignaturePrefix + " soon", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seconds, err := verifyShare(tt.key, tt.text)
+
+			if (err != nil) != tt.err {
+				t.Fatalf("verifyShare() error = %v, want error %v", err, tt.err)
+			}
+
+			if seconds != tt.seconds {
+				t.Errorf("verifyShare() = %d seconds, want %d", seconds, tt.seconds)
+			}
+		})
+	}
+}
+
+func TestShareKeyRoundTrip(t *testing.T) {
+	public := ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize)).Public().(ed25519.PublicKey)
+
+	tests := []struct {
+		name string
+		text string
+		err  bool
+	}{
+		{"as printed", encodeShareKey(public), false},
+		{"pasted with a newline", encodeShareKey(public) + "\n", false},
+		{"cut short", encodeShareKey(public)[:20], true},
+		{"not base64", "not a key!", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeShareKey(tt.text)
+
+			if (err != nil) != tt.err {
+				t.Fatalf("decodeShareKey(%q) error = %v, want error %v", tt.text, err, tt.err)
+			}
+
+			if err == nil && !got.Equal(public) {
+				t.Errorf("decodeShareKey(%q) = %x, want %x", tt.text, got, public)
+			}
+		})
+	}
+}
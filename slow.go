@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// thinkTimer holds back Enter until a guess has been thought about for at
+// least --slow seconds, counting down on the status line. The countdown goes
+// in front of whatever else is on the status line, like the trainer's hints,
+// and comes off again once the time is up.
+type thinkTimer struct {
+	stat *Screen
+	min  time.Duration
+
+	mutex   sync.Mutex
+	started time.Time
+	shown   string // countdown on the status line right now
+	done    chan struct{}
+}
+
+func startThinkTimer(stat *Screen, min time.Duration) *thinkTimer {
+	timer := &thinkTimer{
+		stat:    stat,
+		min:     min,
+		started: time.Now(),
+		done:    make(chan struct{}),
+	}
+
+	go timer.run()
+
+	return timer
+}
+
+// Reset starts the countdown over for the next guess.
+func (timer *thinkTimer) Reset() {
+	timer.mutex.Lock()
+	defer timer.mutex.Unlock()
+
+	timer.started = time.Now()
+}
+
+// Remaining is how much longer before Enter is accepted.
+func (timer *thinkTimer) Remaining() time.Duration {
+	timer.mutex.Lock()
+	defer timer.mutex.Unlock()
+
+	return timer.min - time.Since(timer.started)
+}
+
+func (timer *thinkTimer) Stop() {
+	close(timer.done)
+}
+
+func (timer *thinkTimer) run() {
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		timer.update()
+
+		select {
+		case <-timer.done:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// update puts the seconds left on the status line when they change.
+func (timer *thinkTimer) update() {
+	countdown := ""
+	if remaining := timer.Remaining(); remaining > 0 {
+		countdown = fmt.Sprintf("think %ds", (remaining+time.Second-1)/time.Second)
+	}
+
+	line := timer.stat.Line(statusLine())
+
+	rest := line
+	if timer.shown != "" && strings.HasPrefix(line, timer.shown) {
+		rest = strings.TrimPrefix(strings.TrimPrefix(line, timer.shown), " | ")
+	}
+
+	next := rest
+
+	switch {
+	case countdown == "":
+	case rest == "":
+		next = countdown
+	default:
+		next = countdown + " | " + rest
+	}
+
+	if next != line {
+		_, _ = timer.stat.WriteString(statusLine(), next)
+	}
+
+	timer.shown = countdown
+}
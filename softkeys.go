@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// keyboardLetters are the rows of the on-screen keyboard.
+var keyboardLetters = []string{"QWERTYUIOP", "ASDFGHJKL", "ZXCVBNM"}
+
+// SoftKeyDelete is typed at the --soft-keyboard prompt to delete a letter.
+const SoftKeyDelete = "-"
+
+// softKeyboard reads keys for --soft-keyboard, for terminals where reading
+// single keys doesn't work. Instead of raw keys it reads whole lines typed at
+// a prompt under the board, the way the terminal normally takes input, so it
+// works anywhere a line can be typed. Letters are picked by the numbers shown
+// next to them on the on-screen keyboard, 0 or an empty line is Enter, and -
+// deletes. Anything else typed is read as keys, so letters work too where
+// they can be typed.
+type softKeyboard struct {
+	stat   *Screen
+	line   int
+	input  *bufio.Reader
+	queued []rune
+}
+
+func newSoftKeyboard(line int) *softKeyboard {
+	return &softKeyboard{line: line, input: bufio.NewReader(os.Stdin)}
+}
+
+func (soft *softKeyboard) ReadKey() (rune, error) {
+	for len(soft.queued) == 0 {
+		soft.stat.Prompt(soft.line, "keys (0 is Enter, - deletes)> ")
+
+		typed, err := soft.input.ReadString('\n')
+
+		soft.stat.Entered(soft.line)
+
+		if err != nil && (err != io.EOF || typed == "") {
+			return 0, err
+		}
+
+		soft.queued = softKeys(typed)
+	}
+
+	pressed := soft.queued[0]
+	soft.queued = soft.queued[1:]
+
+	return pressed, nil
+}
+
+func (soft *softKeyboard) Close() error {
+	return nil
+}
+
+// softKeys turns a line typed at the prompt into the keys it stands for.
+func softKeys(typed string) []rune {
+	fields := strings.Fields(typed)
+	if len(fields) == 0 {
+		return []rune{KeyCodeEnter}
+	}
+
+	keys := []rune{}
+
+	for _, field := range fields {
+		if field == SoftKeyDelete {
+			keys = append(keys, KeyCodeMacBackspace)
+			continue
+		}
+
+		n, err := strconv.Atoi(field)
+		if err != nil {
+			keys = append(keys, []rune(field)...)
+			continue
+		}
+
+		if n == 0 {
+			keys = append(keys, KeyCodeEnter)
+		} else if key := softKeyLetter(n); key != 0 {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
+
+// softKeyNumber is the number a letter is picked with, counting across the
+// rows of the on-screen keyboard from 1.
+func softKeyNumber(key rune) int {
+	return strings.IndexRune(strings.Join(keyboardLetters, ""), key) + 1
+}
+
+func softKeyLetter(n int) rune {
+	letters := strings.Join(keyboardLetters, "")
+	if n < 1 || n > len(letters) {
+		return 0
+	}
+
+	return rune(letters[n-1])
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// remainingCandidates filters the pool down to the words that would have
+// scored every guess exactly like the answer did.
+func remainingCandidates(guesses []string, answer string, pool []string) []string {
+	set := newCandidateSet(pool)
+
+	for _, guess := range guesses {
+		set = set.filter(guess, feedback(guess, answer))
+	}
+
+	return set.words
+}
+
+// candidateSet is a list of candidates along with where each one sits in the
+// evaluation cache's rows, so the loops that score every guess against every
+// candidate can look patterns up instead of working them out.
+type candidateSet struct {
+	words   []string
+	columns []int // nil when the cache isn't loaded or doesn't cover them
+}
+
+func newCandidateSet(words []string) candidateSet {
+	return candidateSet{words: words, columns: evalCache.Columns(words)}
+}
+
+// pattern is the pattern the guess gets against the i'th candidate, row being
+// the guess's row of the evaluation cache if it has one.
+func (set candidateSet) pattern(row []Pattern, guess string, i int) Pattern {
+	if row != nil && set.columns != nil {
+		return row[set.columns[i]]
+	}
+
+	return feedback(guess, set.words[i])
+}
+
+// countPatterns counts how many candidates would give each pattern to the
+// guess, reusing counts.
+func (set candidateSet) countPatterns(guess string, counts []int) {
+	for i := range counts {
+		counts[i] = 0
+	}
+
+	row := evalCache.Row(guess)
+	if row != nil && set.columns != nil {
+		for _, column := range set.columns {
+			counts[row[column]]++
+		}
+
+		return
+	}
+
+	for _, candidate := range set.words {
+		counts[feedback(guess, candidate)]++
+	}
+}
+
+// filter keeps the candidates that would give the guess the pattern.
+func (set candidateSet) filter(guess string, pattern Pattern) candidateSet {
+	row := evalCache.Row(guess)
+	kept := candidateSet{words: []string{}}
+
+	if set.columns != nil {
+		kept.columns = []int{}
+	}
+
+	for i, candidate := range set.words {
+		if set.pattern(row, guess, i) != pattern {
+			continue
+		}
+
+		kept.words = append(kept.words, candidate)
+
+		if set.columns != nil {
+			kept.columns = append(kept.columns, set.columns[i])
+		}
+	}
+
+	return kept
+}
+
+// letterFrequency summarizes which letters show up in the most candidates,
+// skipping letters already guessed since they've told all they can.
+func letterFrequency(candidates []string, guesses []string, top int) string {
+	guessed := map[rune]bool{}
+
+	for _, guess := range guesses {
+		for _, r := range guess {
+			guessed[r] = true
+		}
+	}
+
+	counts := map[rune]int{}
+
+	for _, candidate := range candidates {
+		seen := map[rune]bool{}
+
+		for _, r := range candidate {
+			if !seen[r] && !guessed[r] {
+				seen[r] = true
+				counts[r]++
+			}
+		}
+	}
+
+	letters := make([]rune, 0, len(counts))
+	for r := range counts {
+		letters = append(letters, r)
+	}
+
+	sort.Slice(letters, func(i, j int) bool {
+		if counts[letters[i]] == counts[letters[j]] {
+			return letters[i] < letters[j]
+		}
+
+		return counts[letters[i]] > counts[letters[j]]
+	})
+
+	if len(letters) > top {
+		letters = letters[:top]
+	}
+
+	parts := make([]string, len(letters))
+	for i, r := range letters {
+		parts[i] = fmt.Sprintf("%c %d%%", r, counts[r]*100/len(candidates))
+	}
+
+	if len(parts) == 0 {
+		return fmt.Sprintf("%d left", len(candidates))
+	}
+
+	return fmt.Sprintf("%d left, most common: %s", len(candidates), strings.Join(parts, ", "))
+}
+
+// Where --suggest picks suggested guesses from.
+const (
+	SuggestPersonal = "personal"
+	SuggestFull     = "full"
+)
+
+// hardModeGuesses filters the pool down to the guesses hard mode allows. The
+// candidates always qualify, so they're used when nothing else does.
+func hardModeGuesses(pool []string, candidates []string, known *Constraints) []string {
+	allowed := []string{}
+
+	for _, guess := range pool {
+		if known.HardModeViolation(guess) == nil {
+			allowed = append(allowed, guess)
+		}
+	}
+
+	if len(allowed) == 0 {
+		return candidates
+	}
+
+	return allowed
+}
+
+// suggestionPool is the words --suggest picks from. The personal pool is the
+// valid words the player has guessed before, or every valid guess if there
+// aren't any yet.
+func (gs *GameStats) suggestionPool(source string) []string {
+	pool := []string{}
+
+	if source == SuggestPersonal {
+		seen := map[string]bool{}
+
+		for _, game := range gs.History {
+			for _, guess := range game.Guesses {
+				if !seen[guess] && isWord(guess) {
+					seen[guess] = true
+					pool = append(pool, guess)
+				}
+			}
+		}
+
+		if len(pool) > 0 {
+			return pool
+		}
+	}
+
+	for i := 0; i < dictionary.Len(); i++ {
+		pool = append(pool, dictionary.Word(i))
+	}
+
+	return pool
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"os"
+	"sync"
+	"time"
+)
+
+// Cue is a pattern of terminal bells, one entry per bell giving the pause
+// before it.
+type Cue []time.Duration
+
+// Cues for what just happened, told apart by how many bells and how quickly
+// they come.
+var (
+	CueKey     = Cue{0}
+	CueInvalid = Cue{0, 80 * time.Millisecond}
+	CueRow     = Cue{0, 300 * time.Millisecond}
+	CueWin     = Cue{0, 80 * time.Millisecond, 80 * time.Millisecond}
+	CueLoss    = Cue{0, 400 * time.Millisecond, 400 * time.Millisecond}
+)
+
+// soundEnabled is set when sound cues are turned on and not muted.
+var soundEnabled bool
+
+// ringing tracks the cues still playing so the last one isn't cut off when
+// the game exits.
+var ringing sync.WaitGroup
+
+// play rings the cue without waiting for it to finish.
+func (c Cue) play() {
+	if !soundEnabled {
+		return
+	}
+
+	ringing.Add(1)
+
+	go func() {
+		defer ringing.Done()
+		c.ring()
+	}()
+}
+
+// waitForCues blocks until every cue has finished ringing.
+func waitForCues() {
+	ringing.Wait()
+}
+
+func (c Cue) ring() {
+	for _, pause := range c {
+		time.Sleep(pause)
+
+		_, _ = os.Stdout.WriteString("\a")
+	}
+}
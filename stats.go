@@ -0,0 +1,227 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+)
+
+type StatsCommand struct {
+	Letters   bool `long:"letters" description:"Compare how often each letter is guessed vs how often it's in the answer"`
+	Positions bool `long:"positions" description:"Show how often each position is green by guess number"`
+	Watch     bool `long:"watch" description:"Print a one line summary for status bars and prompts, never writing to the stats file"`
+}
+
+func (cmd *StatsCommand) Execute(_ []string) error {
+	if cmd.Watch {
+		return printWatchLine()
+	}
+
+	gamestats := loadGameStats()
+	gamestats.applyTheme()
+
+	if gamestats.DefaultToHardMode {
+		args.HardMode = true
+	}
+
+	if cmd.Letters {
+		gamestats.printLetters()
+		return nil
+	}
+
+	if cmd.Positions {
+		gamestats.printPositions()
+		return nil
+	}
+
+	gamestats.print(nil)
+
+	return nil
+}
+
+// printLetters renders a table of how often each letter shows up in guesses
+// compared to answers across the game history.
+func (gs *GameStats) printLetters() {
+	guessed := map[rune]int{}
+	answered := map[rune]int{}
+	max := 0
+
+	for _, game := range gs.History {
+		for _, guess := range game.Guesses {
+			for _, r := range guess {
+				guessed[r]++
+				if guessed[r] > max {
+					max = guessed[r]
+				}
+			}
+		}
+
+		for _, r := range game.Answer {
+			answered[r]++
+			if answered[r] > max {
+				max = answered[r]
+			}
+		}
+	}
+
+	fmt.Print("Letter Usage\n\n")
+
+	if max == 0 {
+		fmt.Println("No games played yet")
+		return
+	}
+
+	fmt.Printf("   %-*s  %s\n", int(MaxHistogramBarLength)+5, "Guesses", "Answers")
+
+	never := []string{}
+
+	for r := 'A'; r <= 'Z'; r++ {
+		// tint the guess bar by how it compares to the answers
+		sprintf := hintColorFns[KeyHintLocated]
+		if guessed[r] == 0 && answered[r] > 0 {
+			sprintf = hintColorFns[KeyHintNotInWord]
+		} else if guessed[r] < answered[r] {
+			sprintf = hintColorFns[KeyHintSomewhere]
+		}
+
+		guessBar := histogramBar(guessed[r], max)
+		answerBar := histogramBar(answered[r], max)
+
+		fmt.Printf("%c: %4d %s  %4d %s\n", r, guessed[r], sprintf(guessBar)+strings.Repeat(" ", int(MaxHistogramBarLength)-len([]rune(guessBar))), answered[r], answerBar)
+
+		if guessed[r] == 0 {
+			never = append(never, string(r))
+		}
+	}
+
+	if len(never) > 0 {
+		fmt.Printf("\nNever guessed: %s\n", color.RedString(strings.Join(never, ", ")))
+	}
+}
+
+// histogramBar draws a histogram bar scaled so that max fills the whole bar.
+func histogramBar(count int, max int) string {
+	if max == 0 {
+		return ""
+	}
+
+	return strings.Repeat("█", int(MaxHistogramBarLength*float64(count)/float64(max)))
+}
+
+// printPositions renders a grid of how often each slot was green, with a row
+// per guess number, across the game history.
+func (gs *GameStats) printPositions() {
+	hits := make([][]int, TotalGuesses)
+	totals := make([]int, TotalGuesses)
+
+	for i := range hits {
+		hits[i] = make([]int, WordLength)
+	}
+
+	for _, game := range gs.History {
+		for i, guess := range game.Guesses {
+			if i >= TotalGuesses || len(guess) != WordLength || len(game.Answer) != WordLength {
+				continue
+			}
+
+			totals[i]++
+
+			for j, hint := range scoreGuess(guess, game.Answer) {
+				if hint == KeyHintLocated {
+					hits[i][j]++
+				}
+			}
+		}
+	}
+
+	fmt.Print("Green Hits by Position\n\n")
+
+	if totals[0] == 0 {
+		fmt.Println("No games played yet")
+		return
+	}
+
+	header := "   "
+	for j := 0; j < WordLength; j++ {
+		header += fmt.Sprintf("%5d", j+1)
+	}
+
+	fmt.Println(header)
+
+	for i := 0; i < TotalGuesses; i++ {
+		fmt.Printf("%d: ", i+1)
+
+		for j := 0; j < WordLength; j++ {
+			if totals[i] == 0 {
+				fmt.Printf("%5s", "-")
+				continue
+			}
+
+			percent := hits[i][j] * 100 / totals[i]
+
+			sprintf := hintColorFns[KeyHintNotInWord]
+			if percent >= 67 {
+				sprintf = hintColorFns[KeyHintLocated]
+			} else if percent >= 34 {
+				sprintf = hintColorFns[KeyHintSomewhere]
+			}
+
+			fmt.Print(sprintf(fmt.Sprintf("%4d%%", percent)))
+		}
+
+		fmt.Printf("  (%d)\n", totals[i])
+	}
+}
+
+// printWatchLine prints the streak and how today's daily is going on one line,
+// e.g. "streak 12, daily 4/6", for tmux status bars and shell prompts. It's
+// run often and alongside games, so unlike loadGameStats it never writes to
+// the stats file and never waits on a passphrase prompt.
+func printWatchLine() error {
+	savePath, _, err := statsPath()
+	if err != nil {
+		return err
+	}
+
+	gamestats := &GameStats{}
+
+	raw, err := ioutil.ReadFile(savePath)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	if err == nil {
+		if isEncryptedStats(raw) && os.Getenv(StatsPassphraseEnv) == "" {
+			return fmt.Errorf("stats are encrypted, set %s to watch them", StatsPassphraseEnv)
+		}
+
+		raw, _, err = decryptStats(raw)
+		if err != nil {
+			return err
+		}
+
+		err = json.Unmarshal(raw, gamestats)
+		if err != nil {
+			return err
+		}
+	}
+
+	parseWordLists()
+	gamestats.migrateHistory()
+
+	today := &localProfile{stats: gamestats}
+	today.findToday(int(time.Since(dailyEpoch).Hours() / 24))
+
+	if today.today == "" {
+		today.today = "not played"
+	}
+
+	fmt.Printf("streak %d, daily %s\n", gamestats.Streak, today.today)
+
+	return nil
+}
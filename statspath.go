@@ -0,0 +1,50 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// StatsPathEnv names the environment variable that overrides where stats are
+// kept.
+const StatsPathEnv = "WORDLE_STATS"
+
+// statsPath picks the file stats are kept in. The home directory is preferred
+// but when it can't be found, e.g. in containers or for service accounts
+// without one, the XDG data directory and then the current directory are
+// used instead. fallback reports whether the home directory was skipped.
+func statsPath() (savePath string, fallback bool, err error) {
+	if override := os.Getenv(StatsPathEnv); override != "" {
+		return override, false, nil
+	}
+
+	if home, err := os.UserHomeDir(); err == nil {
+		return filepath.Join(home, ".wordle"), false, nil
+	}
+
+	if data := os.Getenv("XDG_DATA_HOME"); data != "" {
+		dir := filepath.Join(data, "wordle")
+		if err := os.MkdirAll(dir, 0755); err == nil {
+			return filepath.Join(dir, "stats.json"), true, nil
+		}
+	}
+
+	if cwd, err := os.Getwd(); err == nil {
+		return filepath.Join(cwd, ".wordle"), true, nil
+	}
+
+	return "", true, errors.New("no home directory, XDG_DATA_HOME, or working directory")
+}
+
+// warnStatsPath lets the player know when stats aren't going where they
+// normally would, and how to choose where they go instead.
+func warnStatsPath(savePath string, err error) {
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: stats won't be saved, %v. Set %s to a file path to keep them.\n", err, StatsPathEnv)
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "warning: couldn't find your home directory, stats are kept in %s. Set %s to a file path to keep them elsewhere.\n", savePath, StatsPathEnv)
+}
@@ -0,0 +1,362 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// Built in strategies for --strategy. An external one is named exec:COMMAND.
+const (
+	StrategyExpected  = "expected"
+	StrategyFrequency = "frequency"
+	StrategyEntropy   = "entropy"
+	StrategyMinimax   = "minimax"
+
+	StrategyExecPrefix = "exec:"
+
+	// StrategyTimeout is how long an external strategy gets to answer, and
+	// to exit once it's told to.
+	StrategyTimeout = 5 * time.Second
+)
+
+// SolverState is what a strategy knows when it picks the next guess.
+// Patterns are spelled out like Pattern.String, one per guess.
+type SolverState struct {
+	Guesses    []string `json:"guesses"`
+	Patterns   []string `json:"patterns"`
+	Candidates []string `json:"candidates"`
+	HardMode   bool     `json:"hard_mode"`
+
+	// Pool is the words the guess can be picked from. It isn't sent to
+	// external strategies, which have their own word lists.
+	Pool []string `json:"-"`
+}
+
+// Strategy picks a guess to suggest, or returns an empty string if it has
+// nothing to suggest. Close stops it when the game is over.
+type Strategy interface {
+	SuggestGuess(state *SolverState) string
+	Close() error
+}
+
+// newStrategy looks up a built in strategy by name or starts an external one.
+func newStrategy(name string) (Strategy, error) {
+	if strings.HasPrefix(name, StrategyExecPrefix) {
+		return startExternalStrategy(strings.TrimPrefix(name, StrategyExecPrefix))
+	}
+
+	switch name {
+	case StrategyExpected:
+		return bucketStrategy(expectedScore), nil
+	case StrategyEntropy:
+		return bucketStrategy(entropyScore), nil
+	case StrategyMinimax:
+		return bucketStrategy(minimaxScore), nil
+	case StrategyFrequency:
+		return frequencyStrategy{}, nil
+	}
+
+	return nil, fmt.Errorf("unknown strategy %q, expected %s, %s, %s, %s, or %sCOMMAND", name,
+		StrategyExpected, StrategyFrequency, StrategyEntropy, StrategyMinimax, StrategyExecPrefix)
+}
+
+// bucketStrategy sorts the candidates into buckets by the pattern each guess
+// would get and suggests the guess whose buckets score lowest.
+type bucketStrategy func(counts []int, total int) float64
+
+// expectedScore is proportional to the number of candidates expected to be
+// left.
+func expectedScore(counts []int, _ int) float64 {
+	score := 0
+
+	for _, count := range counts {
+		score += count * count
+	}
+
+	return float64(score)
+}
+
+// entropyScore is the information the guess is expected to give, negated so
+// lower is better.
+func entropyScore(counts []int, total int) float64 {
+	entropy := 0.0
+
+	for _, count := range counts {
+		if count > 0 {
+			p := float64(count) / float64(total)
+			entropy -= p * math.Log2(p)
+		}
+	}
+
+	return -entropy
+}
+
+// minimaxScore is the most candidates that could be left, the worst case.
+func minimaxScore(counts []int, _ int) float64 {
+	worst := 0
+
+	for _, count := range counts {
+		if count > worst {
+			worst = count
+		}
+	}
+
+	return float64(worst)
+}
+
+func (score bucketStrategy) SuggestGuess(state *SolverState) string {
+	if len(state.Candidates) <= 2 {
+		return firstCandidate(state)
+	}
+
+	set := newCandidateSet(state.Candidates)
+	counts := make([]int, PatternCount)
+
+	return bestGuess(state, func(guess string) float64 {
+		set.countPatterns(guess, counts)
+
+		return score(counts, len(state.Candidates))
+	})
+}
+
+func (bucketStrategy) Close() error {
+	return nil
+}
+
+// frequencyStrategy suggests the guess whose letters show up in the most
+// candidates, each letter counted once.
+type frequencyStrategy struct{}
+
+func (frequencyStrategy) SuggestGuess(state *SolverState) string {
+	if len(state.Candidates) <= 2 {
+		return firstCandidate(state)
+	}
+
+	var counts [26]int
+
+	for _, candidate := range state.Candidates {
+		var seen [26]bool
+
+		for i := range candidate {
+			if !seen[candidate[i]-'A'] {
+				seen[candidate[i]-'A'] = true
+				counts[candidate[i]-'A']++
+			}
+		}
+	}
+
+	return bestGuess(state, func(guess string) float64 {
+		var seen [26]bool
+
+		total := 0
+
+		for i := range guess {
+			if !seen[guess[i]-'A'] {
+				seen[guess[i]-'A'] = true
+				total += counts[guess[i]-'A']
+			}
+		}
+
+		return -float64(total)
+	})
+}
+
+func (frequencyStrategy) Close() error {
+	return nil
+}
+
+// bestGuess picks the guess from the pool with the lowest score. Ties go to a
+// guess that could be the answer, then alphabetically.
+func bestGuess(state *SolverState, score func(guess string) float64) string {
+	possible := map[string]bool{}
+	for _, candidate := range state.Candidates {
+		possible[candidate] = true
+	}
+
+	best := ""
+	bestScore := 0.0
+
+	for _, guess := range state.Pool {
+		s := score(guess)
+
+		switch {
+		case best == "" || s < bestScore:
+		case s > bestScore:
+			continue
+		case possible[guess] != possible[best]:
+			if !possible[guess] {
+				continue
+			}
+		case guess > best:
+			continue
+		}
+
+		best, bestScore = guess, s
+	}
+
+	return best
+}
+
+// firstCandidate is the suggestion once it's down to guessing between the
+// last one or two candidates.
+func firstCandidate(state *SolverState) string {
+	if len(state.Candidates) == 0 {
+		return ""
+	}
+
+	return state.Candidates[0]
+}
+
+// externalStrategy is a strategy run as a separate program. It's sent the
+// state as one line of JSON on stdin for every suggestion and answers with a
+// line like {"guess": "CRANE"} on stdout. Its stderr is discarded so it can't
+// garble the board.
+//
+// A suggestion that isn't a word, or breaks hard mode, isn't shown. The next
+// state sent says why, e.g. "rejected": {"code": "NOT_A_WORD", ...}, so the
+// program can tell. An answer that takes longer than StrategyTimeout is
+// skipped.
+type externalStrategy struct {
+	cmd      *exec.Cmd
+	in       io.WriteCloser
+	enc      *json.Encoder
+	replies  chan string
+	late     int        // replies still to come for suggestions that timed out
+	rejected *Rejection // why the last suggestion wasn't used
+}
+
+// externalState is the state as it's sent to an external strategy.
+type externalState struct {
+	*SolverState
+	Rejected *Rejection `json:"rejected,omitempty"`
+}
+
+func startExternalStrategy(command string) (*externalStrategy, error) {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("%s needs a command", StrategyExecPrefix)
+	}
+
+	cmd := exec.Command(fields[0], fields[1:]...)
+
+	in, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	err = cmd.Start()
+	if err != nil {
+		return nil, err
+	}
+
+	s := &externalStrategy{
+		cmd:     cmd,
+		in:      in,
+		enc:     json.NewEncoder(in),
+		replies: make(chan string),
+	}
+
+	// replies are read on the side so waiting on them can time out
+	go func() {
+		defer close(s.replies)
+
+		scanner := bufio.NewScanner(out)
+		for scanner.Scan() {
+			s.replies <- scanner.Text()
+		}
+	}()
+
+	return s, nil
+}
+
+func (s *externalStrategy) SuggestGuess(state *SolverState) string {
+	err := s.enc.Encode(externalState{state, s.rejected})
+	if err != nil {
+		return ""
+	}
+
+	s.rejected = nil
+	timeout := time.After(StrategyTimeout)
+
+	for {
+		select {
+		case line, ok := <-s.replies:
+			if !ok {
+				return ""
+			}
+
+			if s.late > 0 {
+				s.late--
+				continue
+			}
+
+			return s.checkReply(state, line)
+		case <-timeout:
+			s.late++
+			return ""
+		}
+	}
+}
+
+// checkReply reads the guess out of a reply, holding on to why it can't be
+// suggested if it can't.
+func (s *externalStrategy) checkReply(state *SolverState, line string) string {
+	var reply struct {
+		Guess string `json:"guess"`
+	}
+
+	err := json.Unmarshal([]byte(line), &reply)
+	if err != nil || reply.Guess == "" {
+		return ""
+	}
+
+	guess := strings.ToUpper(reply.Guess)
+
+	s.rejected = wordRejection(guess)
+	if s.rejected == nil && state.HardMode && len(state.Candidates) > 0 {
+		// every candidate gives the same patterns, so any will do as the answer
+		s.rejected = buildConstraints(state.Guesses, state.Candidates[0]).HardModeViolation(guess)
+	}
+
+	if s.rejected != nil {
+		return ""
+	}
+
+	return guess
+}
+
+// Close ends the program's input, which it should take as its cue to exit,
+// and kills it if it hasn't within StrategyTimeout.
+func (s *externalStrategy) Close() error {
+	_ = s.in.Close()
+
+	exited := make(chan error, 1)
+
+	// stdout has to be read to the end before waiting on the program
+	go func() {
+		for range s.replies {
+		}
+
+		exited <- s.cmd.Wait()
+	}()
+
+	select {
+	case err := <-exited:
+		return err
+	case <-time.After(StrategyTimeout):
+		_ = s.cmd.Process.Kill()
+
+		return <-exited
+	}
+}
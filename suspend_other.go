@@ -0,0 +1,12 @@
+//go:build windows || plan9
+// +build windows plan9
+
+package main
+
+import (
+	"github.com/mattn/go-tty"
+	"golang.org/x/term"
+)
+
+// watchSuspend does nothing, there's no job control to deal with here.
+func watchSuspend(ty *tty.TTY, stat *Screen, cooked *term.State) {}
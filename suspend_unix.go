@@ -0,0 +1,50 @@
+//go:build !windows && !plan9
+// +build !windows,!plan9
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/mattn/go-tty"
+	"golang.org/x/term"
+)
+
+// watchSuspend handles Ctrl+Z by putting the terminal back the way it was
+// found before stopping, then restoring raw input and redrawing the board once
+// the process is continued.
+func watchSuspend(ty *tty.TTY, stat *Screen, cooked *term.State) {
+	if cooked == nil {
+		return
+	}
+
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, syscall.SIGTSTP)
+
+	go func() {
+		for range c {
+			fd := int(ty.Input().Fd())
+
+			raw, err := term.GetState(fd)
+			if err != nil {
+				continue
+			}
+
+			disableMouse()
+			_ = term.Restore(fd, cooked)
+			fmt.Print("\033[?25h\n") // show cursor
+
+			// stop for real, this blocks until SIGCONT
+			signal.Reset(syscall.SIGTSTP)
+			_ = syscall.Kill(os.Getpid(), syscall.SIGTSTP)
+			signal.Notify(c, syscall.SIGTSTP)
+
+			_ = term.Restore(fd, raw)
+			_ = stat.Redraw()
+			enableMouse()
+		}
+	}()
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	TelegramAPI         = "https://api.telegram.org/bot"
+	TelegramPollTimeout = 50 * time.Second
+	TelegramRetryDelay  = 5 * time.Second
+)
+
+type TelegramCommand struct {
+	Token string `long:"token" required:"true" description:"Bot token from @BotFather" value-name:"T"`
+}
+
+// telegramBot talks to the Telegram bot API by long polling, so it works from
+// behind NAT without a webhook.
+type telegramBot struct {
+	token  string
+	client *http.Client
+}
+
+type telegramUpdate struct {
+	UpdateID int              `json:"update_id"`
+	Message  *telegramMessage `json:"message"`
+}
+
+type telegramMessage struct {
+	Chat struct {
+		ID int64 `json:"id"`
+	} `json:"chat"`
+	From *struct {
+		FirstName string `json:"first_name"`
+		Username  string `json:"username"`
+	} `json:"from"`
+	Text string `json:"text"`
+}
+
+// sender is what the leaderboard calls whoever sent the message.
+func (msg *telegramMessage) sender() string {
+	switch {
+	case msg.From == nil:
+		return ""
+	case msg.From.Username != "":
+		return "@" + msg.From.Username
+	}
+
+	return msg.From.FirstName
+}
+
+// telegramError is an error returned by the bot API itself, as opposed to
+// the network in between.
+type telegramError struct {
+	Code        int
+	Description string
+}
+
+func (err *telegramError) Error() string {
+	return fmt.Sprintf("telegram: %d %s", err.Code, err.Description)
+}
+
+func (cmd *TelegramCommand) Execute(_ []string) error {
+	gamestats := loadGameStats()
+	parseWordLists()
+
+	bot := &telegramBot{
+		token:  cmd.Token,
+		client: &http.Client{Timeout: TelegramPollTimeout + 10*time.Second},
+	}
+
+	fmt.Println("Telegram bot running, press Ctrl+C to stop")
+
+	offset := 0
+
+	for {
+		updates, err := bot.getUpdates(offset)
+		if err != nil {
+			// a bad token won't fix itself
+			var apiErr *telegramError
+			if errors.As(err, &apiErr) && apiErr.Code == http.StatusUnauthorized {
+				return err
+			}
+
+			fmt.Fprintln(os.Stderr, err)
+			time.Sleep(TelegramRetryDelay)
+
+			continue
+		}
+
+		for _, update := range updates {
+			offset = update.UpdateID + 1
+
+			if update.Message == nil {
+				continue
+			}
+
+			chat := update.Message.Chat.ID
+
+			reply, err := gamestats.playChat(fmt.Sprintf("telegram:%d", chat), update.Message.sender(), update.Message.Text, "/")
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "warning: chat stats: %v\n", err)
+			}
+
+			if reply == "" {
+				continue
+			}
+
+			err = bot.sendMessage(chat, reply)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+			}
+		}
+	}
+}
+
+func (bot *telegramBot) getUpdates(offset int) ([]telegramUpdate, error) {
+	params := url.Values{}
+	params.Set("offset", strconv.Itoa(offset))
+	params.Set("timeout", strconv.Itoa(int(TelegramPollTimeout.Seconds())))
+	params.Set("allowed_updates", `["message"]`)
+
+	var updates []telegramUpdate
+
+	err := bot.call("getUpdates", params, &updates)
+
+	return updates, err
+}
+
+func (bot *telegramBot) sendMessage(chat int64, text string) error {
+	params := url.Values{}
+	params.Set("chat_id", strconv.FormatInt(chat, 10))
+	params.Set("text", text)
+
+	return bot.call("sendMessage", params, nil)
+}
+
+// call runs a bot API method and decodes its result into result, if given.
+func (bot *telegramBot) call(method string, params url.Values, result interface{}) error {
+	resp, err := bot.client.PostForm(TelegramAPI+bot.token+"/"+method, params)
+	if err != nil {
+		// the error includes the url, which includes the token
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			return fmt.Errorf("telegram: %s: %w", method, urlErr.Err)
+		}
+
+		return err
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		OK          bool            `json:"ok"`
+		ErrorCode   int             `json:"error_code"`
+		Description string          `json:"description"`
+		Result      json.RawMessage `json:"result"`
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(&body)
+	if err != nil {
+		return fmt.Errorf("telegram: %s: %w", method, err)
+	}
+
+	if !body.OK {
+		return &telegramError{Code: body.ErrorCode, Description: body.Description}
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	return json.Unmarshal(body.Result, result)
+}
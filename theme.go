@@ -0,0 +1,155 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// Color themes for the hint colors.
+const (
+	ThemeAuto  = "auto"
+	ThemeDark  = "dark"
+	ThemeLight = "light"
+)
+
+// lightHintColorFns swap the plain ANSI yellow and green, which wash out on
+// light backgrounds, for darker shades from the 256 color palette.
+var lightHintColorFns = map[KeyHint]ColorFunc{
+	KeyHintSomewhere: color.New(38, 5, 136).SprintfFunc(),
+	KeyHintLocated:   color.New(38, 5, 28).SprintfFunc(),
+}
+
+// tileColorFns color the letters on the board and keyboard. They're the same
+// as hintColorFns unless the terminal supports truecolor, then the letters
+// are drawn on the website's tile colors.
+var tileColorFns = map[KeyHint]ColorFunc{
+	KeyHintUnknown:   fmt.Sprintf,
+	KeyHintNotInWord: color.RedString,
+	KeyHintSomewhere: color.YellowString,
+	KeyHintLocated:   color.GreenString,
+}
+
+// tilePalettes are the website's tile colors for each theme.
+var tilePalettes = map[string]map[KeyHint][3]int{
+	ThemeDark: {
+		KeyHintNotInWord: {0x3a, 0x3a, 0x3c},
+		KeyHintSomewhere: {0xb5, 0x9f, 0x3b},
+		KeyHintLocated:   {0x53, 0x8d, 0x4e},
+	},
+	ThemeLight: {
+		KeyHintNotInWord: {0x78, 0x7c, 0x7e},
+		KeyHintSomewhere: {0xc9, 0xb4, 0x58},
+		KeyHintLocated:   {0x6a, 0xaa, 0x64},
+	},
+}
+
+// applyTheme sets up every color the game draws with. It picks the hint
+// colors for the terminal's background, preferring --theme over the config
+// and detecting the background when neither says, and uses truecolor tiles
+// when the terminal supports them.
+func (gs *GameStats) applyTheme() {
+	theme := gs.Theme
+	if args.Theme != "" {
+		theme = args.Theme
+	}
+
+	if theme != ThemeDark && theme != ThemeLight {
+		theme = detectTheme()
+	}
+
+	if theme == ThemeLight {
+		for hint, fn := range lightHintColorFns {
+			hintColorFns[hint] = fn
+		}
+	}
+
+	for hint, fn := range hintColorFns {
+		tileColorFns[hint] = fn
+	}
+
+	if !supportsTruecolor() {
+		return
+	}
+
+	for hint, rgb := range tilePalettes[theme] {
+		tileColorFns[hint] = color.New(color.Bold, 38, 2, 255, 255, 255, 48, 2, color.Attribute(rgb[0]), color.Attribute(rgb[1]), color.Attribute(rgb[2])).SprintfFunc()
+	}
+}
+
+// supportsTruecolor checks COLORTERM, which terminals that can show 24 bit
+// color set to "truecolor" or "24bit", and that a multiplexer in between
+// passes it on.
+func supportsTruecolor() bool {
+	if color.NoColor {
+		return false
+	}
+
+	colorterm := os.Getenv("COLORTERM")
+
+	return (colorterm == "truecolor" || colorterm == "24bit") && multiplexerTruecolor()
+}
+
+// detectTheme asks the terminal for its background color, falling back on
+// COLORFGBG, which some terminals set, and then on assuming it's dark.
+func detectTheme() string {
+	if color.NoColor {
+		return ThemeDark
+	}
+
+	if reply, ok := queryBackground(); ok {
+		if luminance, ok := parseBackground(reply); ok {
+			if luminance > 0.5 {
+				return ThemeLight
+			}
+
+			return ThemeDark
+		}
+	}
+
+	// COLORFGBG is "foreground;background" using the 16 ANSI color numbers
+	fgbg := strings.Split(os.Getenv("COLORFGBG"), ";")
+	if bg, err := strconv.Atoi(fgbg[len(fgbg)-1]); err == nil && (bg == 7 || bg >= 9 && bg <= 15) {
+		return ThemeLight
+	}
+
+	return ThemeDark
+}
+
+// parseBackground reads the luminance, from 0 to 1, out of a reply to the
+// OSC 11 query, e.g. "\x1b]11;rgb:ffff/ffff/dddd\x07".
+func parseBackground(reply string) (float64, bool) {
+	start := strings.Index(reply, "rgb:")
+	if start < 0 {
+		return 0, false
+	}
+
+	spec := strings.TrimRight(reply[start+len("rgb:"):], "\x07\x1b\\")
+
+	channels := strings.Split(spec, "/")
+	if len(channels) != 3 {
+		return 0, false
+	}
+
+	weights := []float64{0.2126, 0.7152, 0.0722}
+	luminance := 0.0
+
+	for i, channel := range channels {
+		if len(channel) == 0 || len(channel) > 4 {
+			return 0, false
+		}
+
+		value, err := strconv.ParseUint(channel, 16, 16)
+		if err != nil {
+			return 0, false
+		}
+
+		max := uint64(1)<<(4*uint(len(channel))) - 1
+		luminance += weights[i] * float64(value) / float64(max)
+	}
+
+	return luminance, true
+}
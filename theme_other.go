@@ -0,0 +1,9 @@
+//go:build windows || plan9
+// +build windows plan9
+
+package main
+
+// queryBackground can't ask the console for its background color here.
+func queryBackground() (string, bool) {
+	return "", false
+}
@@ -0,0 +1,132 @@
+//go:build !windows && !plan9
+// +build !windows,!plan9
+
+package main
+
+import (
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// BackgroundQueryTimeout is how long to wait for the terminal to say what its
+// background color is. Almost every terminal answers the DA1 query sent after
+// it straight away, so this is only waited out by the few that answer neither.
+const BackgroundQueryTimeout = 500 * time.Millisecond
+
+// DA1 asks the terminal what it is. Terminals answer queries in the order
+// they're sent, so once the answer to this comes back, like ESC [ ? 62 ; 22 c,
+// there's no background color still on its way to be typed into the game.
+const DA1 = "\x1b[c"
+
+// queryBackground sends the OSC 11 query for the background color straight
+// to the terminal and returns the raw reply.
+func queryBackground() (string, bool) {
+	f, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		return "", false
+	}
+	defer f.Close()
+
+	// Fd would put the file back in blocking mode and break the deadline
+	conn, err := f.SyscallConn()
+	if err != nil {
+		return "", false
+	}
+
+	var state *term.State
+
+	_ = conn.Control(func(fd uintptr) {
+		state, err = term.MakeRaw(int(fd))
+	})
+
+	if err != nil {
+		return "", false
+	}
+
+	defer func() {
+		_ = conn.Control(func(fd uintptr) {
+			_ = term.Restore(int(fd), state)
+		})
+	}()
+
+	// a multiplexer answers for itself, so its answer is only the fallback
+	// for when it won't pass the query on to the real terminal
+	queries := []string{"\x1b]11;?\x1b\\" + DA1}
+	if detectMultiplexer() != MultiplexerNone {
+		queries = append([]string{passthrough("\x1b]11;?\x07") + DA1}, queries...)
+	}
+
+	for _, query := range queries {
+		if reply, ok := askTerminal(f, query); ok {
+			return reply, true
+		}
+	}
+
+	return "", false
+}
+
+// askTerminal sends a query followed by DA1 and reads up to the answer to
+// DA1, returning the reply to the query if one came before it.
+func askTerminal(f *os.File, query string) (string, bool) {
+	_, err := f.WriteString(query)
+	if err != nil {
+		return "", false
+	}
+
+	err = f.SetReadDeadline(time.Now().Add(BackgroundQueryTimeout))
+	if err != nil {
+		return "", false
+	}
+
+	reply := ""
+	buf := make([]byte, 64)
+
+	for {
+		n, err := f.Read(buf)
+		if err != nil {
+			// a reply too late to wait for mustn't end up in the first guess
+			drainTerminal(f)
+
+			return "", false
+		}
+
+		reply += string(buf[:n])
+
+		if match := da1Reply.FindStringIndex(reply); match != nil {
+			reply = reply[:match[0]]
+			break
+		}
+	}
+
+	// the reply ends with either BEL or ST (ESC \)
+	if !strings.HasSuffix(reply, "\x07") && !strings.HasSuffix(reply, "\x1b\\") {
+		return "", false
+	}
+
+	return reply, true
+}
+
+// da1Reply matches the terminal's answer to DA1.
+var da1Reply = regexp.MustCompile(`\x1b\[\?[0-9;]*c`)
+
+// drainTerminal throws away anything the terminal sends until it's been
+// quiet for a moment.
+func drainTerminal(f *os.File) {
+	buf := make([]byte, 64)
+
+	for {
+		err := f.SetReadDeadline(time.Now().Add(BackgroundQueryTimeout))
+		if err != nil {
+			return
+		}
+
+		_, err = f.Read(buf)
+		if err != nil {
+			return
+		}
+	}
+}
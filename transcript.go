@@ -0,0 +1,108 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TranscriptChecksumLength is how many hex digits of the checksum are shown.
+const TranscriptChecksumLength = 12
+
+// transcriptNonce salts the current game's transcript so the checksum of a
+// short game can't be brute forced to spoil the answer before it's revealed.
+var transcriptNonce string
+
+type VerifyTranscriptCommand struct {
+	Show string `long:"show" description:"Print the transcript of a game played on this day to share" value-name:"YYYY-MM-DD"`
+}
+
+func (cmd *VerifyTranscriptCommand) Execute(fields []string) error {
+	if cmd.Show != "" {
+		return showTranscript(cmd.Show)
+	}
+
+	if len(fields) < 4 {
+		return errors.New("expected a checksum followed by a transcript: NUMBER NONCE GUESS...")
+	}
+
+	parseWordLists()
+
+	checksum, nonce, guesses := fields[0], strings.ToLower(fields[2]), fields[3:]
+
+	number, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return fmt.Errorf("invalid puzzle number %q", fields[1])
+	}
+
+	for i, guess := range guesses {
+		guesses[i] = strings.ToUpper(guess)
+		if !isWord(guesses[i]) {
+			return fmt.Errorf("%s isn't a valid guess", guesses[i])
+		}
+	}
+
+	transcript := gameTranscript(number, nonce, guesses)
+
+	if transcriptChecksum(transcript) != strings.ToLower(checksum) {
+		return errors.New("checksum doesn't match the transcript")
+	}
+
+	fmt.Println("Checksum matches the transcript")
+
+	return nil
+}
+
+// newTranscriptNonce picks the salt for a game's transcript.
+func newTranscriptNonce() string {
+	raw := make([]byte, 4)
+	_, _ = rand.Read(raw)
+
+	return hex.EncodeToString(raw)
+}
+
+// gameTranscript lays a game out as "NUMBER NONCE GUESS...", the form it's
+// shared in and checked against.
+func gameTranscript(number int, nonce string, guesses []string) string {
+	return strings.Join(append([]string{fmt.Sprint(number), nonce}, guesses...), " ")
+}
+
+func transcriptChecksum(transcript string) string {
+	sum := sha256.Sum256([]byte(transcript))
+
+	return hex.EncodeToString(sum[:])[:TranscriptChecksumLength]
+}
+
+// showTranscript prints the transcripts of the games on a day that were
+// played with checksums turned on.
+func showTranscript(date string) error {
+	day, err := time.ParseInLocation("2006-01-02", date, time.Local)
+	if err != nil {
+		return fmt.Errorf("invalid date %q, expected YYYY-MM-DD", date)
+	}
+
+	gamestats := loadGameStats()
+	found := false
+
+	for _, game := range gamestats.History {
+		if game.Nonce == "" || game.Date.Local().Format("2006-01-02") != day.Format("2006-01-02") {
+			continue
+		}
+
+		transcript := gameTranscript(game.Number, game.Nonce, game.Guesses)
+		fmt.Printf("%s %s\n", transcriptChecksum(transcript), transcript)
+
+		found = true
+	}
+
+	if !found {
+		return fmt.Errorf("no games with a transcript checksum found on %s", day.Format("2006-01-02"))
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package main
+
+import "testing"
+
+func TestTranscriptChecksum(t *testing.T) {
+	guesses := []string{"CRANE", "SLATE", "SHAKE"}
+	checksum := transcriptChecksum(gameTranscript(1000, "0a1b2c3d", guesses))
+
+	tests := []struct {
+		name    string
+		number  int
+		nonce   string
+		guesses []string
+		match   bool
+	}{
+		{"same game", 1000, "0a1b2c3d", []string{"CRANE", "SLATE", "SHAKE"}, true},
+		{"other puzzle", 1001, "0a1b2c3d", []string{"CRANE", "SLATE", "SHAKE"}, false},
+		{"other salt", 1000, "0a1b2c3e", []string{"CRANE", "SLATE", "SHAKE"}, false},
+		{"guess changed", 1000, "0a1b2c3d", []string{"CRANE", "STALE", "SHAKE"}, false},
+		{"guess left out", 1000, "0a1b2c3d", []string{"CRANE", "SHAKE"}, false},
+		{"guesses swapped", 1000, "0a1b2c3d", []string{"SLATE", "CRANE", "SHAKE"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := transcriptChecksum(gameTranscript(tt.number, tt.nonce, tt.guesses))
+
+			if len(got) != TranscriptChecksumLength {
+				t.Errorf("checksum %q is %d digits, want %d", got, len(got), TranscriptChecksumLength)
+			}
+
+			if (got == checksum) != tt.match {
+				t.Errorf("checksum %s against %s, want match %v", got, checksum, tt.match)
+			}
+		})
+	}
+}
+
+func TestGameTranscript(t *testing.T) {
+	tests := []struct {
+		number  int
+		nonce   string
+		guesses []string
+		want    string
+	}{
+		{1000, "0a1b2c3d", []string{"CRANE", "SHAKE"}, "1000 0a1b2c3d CRANE SHAKE"},
+		{7, "ffffffff", []string{"CRANE"}, "7 ffffffff CRANE"},
+		{1000, "0a1b2c3d", nil, "1000 0a1b2c3d"},
+	}
+
+	for _, tt := range tests {
+		if got := gameTranscript(tt.number, tt.nonce, tt.guesses); got != tt.want {
+			t.Errorf("gameTranscript(%d, %q, %v) = %q, want %q", tt.number, tt.nonce, tt.guesses, got, tt.want)
+		}
+	}
+}
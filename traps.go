@@ -0,0 +1,155 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// KeyTrapToggle turns the trap assistant on and off during a game.
+	KeyTrapToggle = '*'
+	// TrapMinCandidates is how many answers have to be left for it to be a
+	// trap, with fewer guessing through them is usually fine.
+	TrapMinCandidates = 4
+)
+
+// trapAssist is set while the trap assistant is on. It's only ever on in
+// practice games, as it would be cheating in a daily.
+var trapAssist bool
+
+// endingTrap is the answers left when they differ by a single letter, like
+// SHAPE, SHAVE, SHAKE, and SHAME, which can't be guessed through one at a time
+// in the guesses left.
+type endingTrap struct {
+	Candidates []string
+	Position   int
+	Letters    []byte
+}
+
+// findTrap checks whether the answers left are a trap, returning nil if not.
+func findTrap(candidates []string) *endingTrap {
+	if len(candidates) < TrapMinCandidates {
+		return nil
+	}
+
+	position := -1
+
+	for i := 0; i < WordLength; i++ {
+		for _, candidate := range candidates[1:] {
+			if candidate[i] == candidates[0][i] {
+				continue
+			}
+
+			if position != -1 && position != i {
+				return nil
+			}
+
+			position = i
+		}
+	}
+
+	if position == -1 {
+		return nil
+	}
+
+	trap := &endingTrap{Candidates: candidates, Position: position}
+	for _, candidate := range candidates {
+		trap.Letters = append(trap.Letters, candidate[position])
+	}
+
+	sort.Slice(trap.Letters, func(i, j int) bool { return trap.Letters[i] < trap.Letters[j] })
+
+	return trap
+}
+
+// Pattern is what the answers have in common, like SHA_E.
+func (t *endingTrap) Pattern() string {
+	pattern := []byte(t.Candidates[0])
+	pattern[t.Position] = DrillBlank
+
+	return string(pattern)
+}
+
+// probe finds the guess that tests the most of the trap's differing letters
+// at once, and the letters it tests. A probe that's one of the answers is
+// preferred when it tests as many, it might just win. A letter the answers
+// already share, like the T of _IGHT against TIGHT, comes up yellow either way
+// and only tells them apart in the blank's spot.
+func (t *endingTrap) probe(pool []string) (string, []byte) {
+	shared := t.Pattern()
+	best := ""
+	bestCovered := []byte{}
+
+	for _, guess := range pool {
+		covered := []byte{}
+
+		for _, letter := range t.Letters {
+			if guess[t.Position] == letter || (strings.IndexByte(guess, letter) != -1 && strings.IndexByte(shared, letter) == -1) {
+				covered = append(covered, letter)
+			}
+		}
+
+		if len(covered) > len(bestCovered) || (len(covered) == len(bestCovered) && len(covered) > 0 && t.isCandidate(guess) && !t.isCandidate(best)) {
+			best = guess
+			bestCovered = covered
+		}
+	}
+
+	return best, bestCovered
+}
+
+func (t *endingTrap) isCandidate(guess string) bool {
+	for _, candidate := range t.Candidates {
+		if candidate == guess {
+			return true
+		}
+	}
+
+	return false
+}
+
+// trapAdvice describes the trap the game is in and the probe to get out of
+// it for the status line, or nothing if it isn't in one.
+func (gs *GameStats) trapAdvice() string {
+	candidates := remainingCandidates(guessHistory, word, wordList)
+
+	trap := findTrap(candidates)
+	if trap == nil {
+		return ""
+	}
+
+	// the best probe is no help if hard mode won't take it
+	pool := gs.suggestionPool(SuggestFull)
+	if args.HardMode {
+		pool = hardModeGuesses(pool, candidates, buildConstraints(guessHistory, word))
+	}
+
+	advice := fmt.Sprintf("trap: %d answers fit %s", len(candidates), trap.Pattern())
+
+	probe, covered := trap.probe(pool)
+	if len(covered) > 1 {
+		advice += fmt.Sprintf(", %s tests %s", probe, strings.Join(strings.Split(string(covered), ""), " "))
+	}
+
+	return advice
+}
+
+// toggleTrapAssist flips the trap assistant, returning what to say about it.
+func (gs *GameStats) toggleTrapAssist(practice bool) string {
+	if !practice {
+		return "the trap assistant is off for dailies"
+	}
+
+	trapAssist = !trapAssist
+
+	if !trapAssist {
+		return "trap assistant off"
+	}
+
+	if advice := gs.trapAdvice(); advice != "" {
+		return advice
+	}
+
+	return fmt.Sprintf("trap assistant on, %c to turn off", KeyTrapToggle)
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	TwitchServer  = "irc.chat.twitch.tv:6697"
+	TwitchTallies = 5
+)
+
+// twitchChat is a message from the channel, or the error that ended the
+// connection.
+type twitchChat struct {
+	nick string
+	text string
+	err  error
+}
+
+// twitchVotes are the votes for the guess being decided. Each chatter gets one
+// vote, changing it replaces the old one.
+type twitchVotes struct {
+	byNick map[string]string
+	first  map[string]int // when a word got its first vote, to break ties
+}
+
+// playTwitch plays a random word with guesses voted on by a Twitch channel's
+// chat. The board and the running tallies are drawn in the terminal for the
+// stream. Chat's games aren't counted in the stats.
+func playTwitch(channel string, window time.Duration) error {
+	parseWordLists()
+
+	rand.Seed(time.Now().UnixNano())
+	word = wordList[rand.Intn(len(wordList))]
+
+	resetGame()
+
+	// justinfan accounts read chat anonymously, no token needed
+	channel = "#" + strings.ToLower(strings.TrimPrefix(channel, "#"))
+
+	client, err := dialIRC(TwitchServer, true, fmt.Sprintf("justinfan%d", rand.Intn(100000)), "Wordle", channel)
+	if err != nil {
+		return err
+	}
+	defer client.conn.Close()
+
+	chat := make(chan twitchChat)
+
+	go func() {
+		for {
+			nick, text, err := client.next()
+			chat <- twitchChat{nick: nick, text: text, err: err}
+
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	fmt.Printf("  Chat plays %s!\n", channel)
+
+	if args.HardMode {
+		fmt.Println("     Hard Mode")
+	}
+
+	stat, err := newScreen(TotalGuesses + 1 + KeyboardRows + PanelLines) // +1 for "status" line
+	if err != nil {
+		return err
+	}
+	defer stat.Finish()
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for i := 0; i < TotalGuesses; i++ {
+		_, _ = stat.WriteString(boardLine(i), blankRow(i))
+	}
+
+	printKeyboard(stat)
+
+	win := false
+
+	for currentGuess < TotalGuesses && !win {
+		votes := &twitchVotes{byNick: map[string]string{}, first: map[string]int{}}
+		deadline := time.Now().Add(window)
+		decided := false
+
+		votes.print(stat, deadline)
+
+		for !decided {
+			select {
+			case <-interrupt:
+				stat.Finish()
+				fmt.Printf("\nThe word was %s\n", word)
+
+				return nil
+			case msg := <-chat:
+				if msg.err != nil {
+					return msg.err
+				}
+
+				guess := strings.ToUpper(strings.TrimSpace(msg.text))
+				// chat has no stats, so only the rules of the game itself apply
+				if (&GameStats{}).checkGuess(guess) == nil {
+					votes.add(msg.nick, guess)
+					votes.print(stat, deadline)
+				}
+			case <-ticker.C:
+				// nobody voted, keep waiting
+				if time.Now().After(deadline) && len(votes.byNick) == 0 {
+					deadline = time.Now().Add(window)
+				}
+
+				decided = time.Now().After(deadline)
+
+				votes.print(stat, deadline)
+			}
+		}
+
+		guess := votes.winner()
+		guessHistory = append(guessHistory, guess)
+
+		_, _ = stat.WriteString(boardLine(currentGuess), formatGuess(guess, true))
+		printKeyboard(stat)
+
+		win = guess == word
+		currentGuess++
+	}
+
+	_, _ = stat.WriteString(panelLine(), "")
+	_, _ = stat.WriteString(statusLine(), "")
+	stat.Finish()
+
+	if win {
+		fmt.Printf("Chat got it in %d/%d!\n", currentGuess, TotalGuesses)
+	} else {
+		fmt.Printf("The word was %s\n", word)
+	}
+
+	return nil
+}
+
+func (votes *twitchVotes) add(nick string, guess string) {
+	if _, ok := votes.first[guess]; !ok {
+		votes.first[guess] = len(votes.first)
+	}
+
+	votes.byNick[nick] = guess
+}
+
+// tally lists the words voted for, most votes first.
+func (votes *twitchVotes) tally() ([]string, map[string]int) {
+	counts := map[string]int{}
+	for _, guess := range votes.byNick {
+		counts[guess]++
+	}
+
+	words := make([]string, 0, len(counts))
+	for guess := range counts {
+		words = append(words, guess)
+	}
+
+	sort.Slice(words, func(i, j int) bool {
+		if counts[words[i]] != counts[words[j]] {
+			return counts[words[i]] > counts[words[j]]
+		}
+
+		return votes.first[words[i]] < votes.first[words[j]]
+	})
+
+	return words, counts
+}
+
+// winner is the most voted word, the one voted for first on a tie.
+func (votes *twitchVotes) winner() string {
+	words, _ := votes.tally()
+
+	return words[0]
+}
+
+// print shows the leading words and the time left to vote.
+func (votes *twitchVotes) print(stat *Screen, deadline time.Time) {
+	words, counts := votes.tally()
+
+	if len(words) > TwitchTallies {
+		words = words[:TwitchTallies]
+	}
+
+	leaders := make([]string, len(words))
+	for i, guess := range words {
+		leaders[i] = fmt.Sprintf("%s %d", guess, counts[guess])
+	}
+
+	_, _ = stat.WriteString(panelLine(), "Votes: "+strings.Join(leaders, ", "))
+
+	left := time.Until(deadline)
+	if left < 0 {
+		left = 0
+	}
+
+	_, _ = stat.WriteString(statusLine(), fmt.Sprintf("Guess %d: vote in chat, %s left", currentGuess+1, formatClock(left)))
+}
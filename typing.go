@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// TypingTest measures how fast and how accurately each guess is typed.
+type TypingTest struct {
+	first       time.Time
+	last        time.Time
+	keys        int
+	corrections int
+
+	// WPM holds the typing speed of every submitted guess, 0 when the row
+	// wasn't typed out, e.g. it was recalled with the arrow keys.
+	WPM []float64
+}
+
+var typing TypingTest
+
+// Key notes a letter being typed into the current row.
+func (t *TypingTest) Key() {
+	now := time.Now()
+
+	if t.first.IsZero() {
+		t.first = now
+	}
+
+	t.last = now
+	t.keys++
+}
+
+// Backspace notes a typo being corrected.
+func (t *TypingTest) Backspace() {
+	t.corrections++
+}
+
+// Submit wraps up the timing of the current row, speed is measured from the
+// first keystroke to the last.
+func (t *TypingTest) Submit() {
+	wpm := float64(0)
+
+	elapsed := t.last.Sub(t.first)
+	if elapsed > 0 {
+		// a "word" is 5 characters by typing test convention
+		wpm = float64(WordLength) / 5 / elapsed.Minutes()
+	}
+
+	t.WPM = append(t.WPM, wpm)
+	t.first = time.Time{}
+	t.last = time.Time{}
+}
+
+// Accuracy is the share of keystrokes that didn't have to be taken back.
+func (t *TypingTest) Accuracy() float64 {
+	if t.keys == 0 {
+		return 0
+	}
+
+	kept := t.keys - t.corrections
+	if kept < 0 {
+		kept = 0
+	}
+
+	return float64(kept) / float64(t.keys)
+}
+
+// AverageWPM averages the speed of the rows that were typed out.
+func (t *TypingTest) AverageWPM() float64 {
+	total := float64(0)
+	rows := 0
+
+	for _, wpm := range t.WPM {
+		if wpm > 0 {
+			total += wpm
+			rows++
+		}
+	}
+
+	if rows == 0 {
+		return 0
+	}
+
+	return total / float64(rows)
+}
+
+// Summary combines speed and accuracy with how many guesses were left over
+// into a single score for the share block.
+func (t *TypingTest) Summary(win bool) string {
+	wpm := t.AverageWPM()
+	accuracy := t.Accuracy()
+
+	score := wpm * accuracy
+	if win {
+		score *= float64(TotalGuesses-currentGuess) / TotalGuesses
+	} else {
+		score = 0
+	}
+
+	return fmt.Sprintf("⌨️ %.0f WPM, %.0f%% accurate, score %.0f", wpm, accuracy*100, score)
+}
@@ -0,0 +1,303 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/mattn/go-tty"
+)
+
+// ReplayCodeVersion is the first byte of a replay code, so the format can
+// change without old codes being misread.
+const ReplayCodeVersion = 1
+
+const (
+	replayCodeWon = 1 << iota
+	replayCodeHard
+)
+
+// replayCode is what a replay code holds: the guesses and how each was
+// scored, but not the answer, so a game can be watched without being spoiled
+// until its last row.
+type replayCode struct {
+	Number   int
+	Won      bool
+	HardMode bool
+	Guesses  []string
+	Hints    [][]KeyHint
+}
+
+type ViewCommand struct {
+	Encode string `long:"encode" description:"Print the replay code of a game played on this day to share" value-name:"YYYY-MM-DD"`
+	Game   int    `short:"g" long:"game" default:"1" description:"With --encode, which game of the day"`
+
+	Args struct {
+		Code string `positional-arg-name:"code" description:"Replay code to watch"`
+	} `positional-args:"yes"`
+}
+
+func (cmd *ViewCommand) Execute(_ []string) error {
+	if cmd.Encode != "" {
+		return printReplayCode(cmd.Encode, cmd.Game)
+	}
+
+	if cmd.Args.Code == "" {
+		return errors.New("expected a replay code, or --encode YYYY-MM-DD to make one")
+	}
+
+	code, err := decodeReplayCode(cmd.Args.Code)
+	if err != nil {
+		return err
+	}
+
+	gamestats := loadGameStats()
+	gamestats.applyTheme()
+
+	viewReplayCode(code)
+
+	return nil
+}
+
+// printReplayCode prints the replay code of a game from the history.
+func printReplayCode(date string, n int) error {
+	day, err := time.ParseInLocation("2006-01-02", date, time.Local)
+	if err != nil {
+		return fmt.Errorf("invalid date %q, expected YYYY-MM-DD", date)
+	}
+
+	gamestats := loadGameStats()
+
+	record, err := gamestats.findGame(day, n)
+	if err != nil {
+		return err
+	}
+
+	if len(record.Guesses) == 0 {
+		return errors.New("the guesses of that game weren't recorded, it was likely imported from a share block")
+	}
+
+	code := replayCode{
+		Number:   record.Number,
+		Won:      record.Won,
+		HardMode: record.HardMode,
+		Guesses:  record.Guesses,
+	}
+
+	for _, guess := range record.Guesses {
+		code.Hints = append(code.Hints, scoreGuess(guess, record.Answer))
+	}
+
+	fmt.Println(code.encode())
+
+	return nil
+}
+
+// encode packs the game into bytes: the version, flags, the puzzle number as
+// a varint, the number of guesses, and then for each guess its letters five
+// bits apiece in four bytes and its colors as base 3 digits in one. It's
+// base64 encoded to paste anywhere.
+func (code replayCode) encode() string {
+	flags := byte(0)
+	if code.Won {
+		flags |= replayCodeWon
+	}
+
+	if code.HardMode {
+		flags |= replayCodeHard
+	}
+
+	raw := []byte{ReplayCodeVersion, flags}
+
+	number := make([]byte, binary.MaxVarintLen64)
+	raw = append(raw, number[:binary.PutUvarint(number, uint64(code.Number))]...)
+	raw = append(raw, byte(len(code.Guesses)))
+
+	for i, guess := range code.Guesses {
+		letters := uint32(0)
+		for j := 0; j < WordLength; j++ {
+			letters = letters<<5 | uint32(guess[j]-'A')
+		}
+
+		colors := byte(0)
+		for j := WordLength - 1; j >= 0; j-- {
+			colors = colors*3 + hintDigit(code.Hints[i][j])
+		}
+
+		packed := make([]byte, 4)
+		binary.BigEndian.PutUint32(packed, letters)
+		raw = append(append(raw, packed...), colors)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeReplayCode(text string) (*replayCode, error) {
+	invalid := errors.New("that isn't a valid replay code")
+
+	raw, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(strings.TrimSpace(text), "="))
+	if err != nil || len(raw) < 2 {
+		return nil, invalid
+	}
+
+	if raw[0] != ReplayCodeVersion {
+		return nil, fmt.Errorf("replay code version %d isn't supported by this version of wordle", raw[0])
+	}
+
+	code := &replayCode{
+		Won:      raw[1]&replayCodeWon != 0,
+		HardMode: raw[1]&replayCodeHard != 0,
+	}
+
+	number, n := binary.Uvarint(raw[2:])
+	if n <= 0 || len(raw) < 2+n+1 {
+		return nil, invalid
+	}
+
+	code.Number = int(number)
+	count := int(raw[2+n])
+	rest := raw[2+n+1:]
+
+	if len(rest) != count*5 {
+		return nil, invalid
+	}
+
+	for i := 0; i < count; i++ {
+		letters := binary.BigEndian.Uint32(rest[i*5:])
+		colors := rest[i*5+4]
+
+		guess := make([]byte, WordLength)
+		hints := make([]KeyHint, WordLength)
+
+		for j := WordLength - 1; j >= 0; j-- {
+			if letters&31 >= 26 {
+				return nil, invalid
+			}
+
+			guess[j] = byte('A' + letters&31)
+			letters >>= 5
+		}
+
+		for j := 0; j < WordLength; j++ {
+			hints[j] = digitHint(colors % 3)
+			colors /= 3
+		}
+
+		code.Guesses = append(code.Guesses, string(guess))
+		code.Hints = append(code.Hints, hints)
+	}
+
+	return code, nil
+}
+
+func hintDigit(hint KeyHint) byte {
+	switch hint {
+	case KeyHintLocated:
+		return 2
+	case KeyHintSomewhere:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func digitHint(digit byte) KeyHint {
+	switch digit {
+	case 2:
+		return KeyHintLocated
+	case 1:
+		return KeyHintSomewhere
+	default:
+		return KeyHintNotInWord
+	}
+}
+
+// viewReplayCode reveals a replay code's guesses one at a time, advancing
+// each time the spacebar is pressed, like replayGame. Only the colors are
+// known, not the answer, so a lost game ends without giving it away.
+func viewReplayCode(code *replayCode) {
+	initKeyboard()
+
+	title := "Wordle"
+	if code.Number > 0 {
+		title = fmt.Sprintf("Wordle %d", code.Number)
+	}
+
+	if code.HardMode {
+		title += ", Hard Mode"
+	}
+
+	fmt.Println("     " + title)
+
+	ty, err := tty.Open()
+	if err != nil {
+		panic(err)
+	}
+	defer ty.Close()
+
+	stat, err := newScreen(TotalGuesses + 1 + KeyboardRows + PanelLines) // +1 for "status" line
+	if err != nil {
+		panic(err)
+	}
+	defer stat.Finish()
+
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, os.Interrupt)
+
+	go func() {
+		<-c
+
+		ty.Close()
+		stat.Finish()
+		os.Exit(0)
+	}()
+
+	for i := 0; i < TotalGuesses; i++ {
+		_, _ = stat.WriteString(boardLine(i), "     _ _ _ _ _")
+	}
+
+	printKeyboard(stat)
+
+	for i, guess := range code.Guesses {
+		if i >= TotalGuesses {
+			break
+		}
+
+		_, _ = stat.WriteString(statusLine(), "(space to reveal the next guess, q to quit)")
+		stat.Flush()
+
+		for {
+			pressed, err := ty.ReadRune()
+			if err != nil {
+				panic(err)
+			}
+
+			if unicode.ToUpper(pressed) == 'Q' {
+				return
+			}
+
+			if pressed == ' ' {
+				break
+			}
+		}
+
+		for j, hint := range code.Hints[i] {
+			setKeyHint(rune(guess[j]), hint)
+		}
+
+		_, _ = stat.WriteString(boardLine(i), colorGuess(guess, code.Hints[i]))
+		printKeyboard(stat)
+	}
+
+	if code.Won {
+		_, _ = stat.WriteString(statusLine(), fmt.Sprintf("Solved in %d", len(code.Guesses)))
+	} else {
+		_, _ = stat.WriteString(statusLine(), "Not solved")
+	}
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// runeWidth is how many columns a character takes on the terminal, as
+// go-runewidth has it, except that variation selectors take none themselves.
+// An emoji presentation selector widens the character before it instead.
+func runeWidth(r rune) int {
+	if r >= 0xfe00 && r <= 0xfe0f {
+		return 0
+	}
+
+	return runewidth.RuneWidth(r)
+}
+
+// escapeLength is how long the ANSI escape sequence at the start of str is, 0
+// if it doesn't start with one. Colors and cursor movement take no columns.
+func escapeLength(str string) int {
+	if len(str) < 2 || str[0] != '\033' {
+		return 0
+	}
+
+	switch str[1] {
+	case '[':
+		for i := 2; i < len(str); i++ {
+			if str[i] >= 0x40 && str[i] <= 0x7e {
+				return i + 1
+			}
+		}
+	case ']':
+		for i := 2; i < len(str); i++ {
+			if str[i] == '\a' {
+				return i + 1
+			}
+
+			if str[i] == '\033' && i+1 < len(str) && str[i+1] == '\\' {
+				return i + 2
+			}
+		}
+	default:
+		return 2
+	}
+
+	return len(str)
+}
+
+// displayWidth is how many columns str takes on the terminal.
+func displayWidth(str string) int {
+	width := 0
+	prev := 0
+
+	for i := 0; i < len(str); {
+		if n := escapeLength(str[i:]); n > 0 {
+			i += n
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(str[i:])
+		width += runeWidth(r)
+
+		// an emoji presentation selector widens the character before it
+		if r == 0xfe0f && prev == 1 {
+			width++
+		}
+
+		prev = runeWidth(r)
+		i += size
+	}
+
+	return width
+}
+
+// truncateWidth cuts str down to at most width columns, keeping its escape
+// sequences so colors are still turned off.
+func truncateWidth(str string, width int) string {
+	var b strings.Builder
+
+	used := 0
+	prev := 0
+	full := false
+
+	for i := 0; i < len(str); {
+		if n := escapeLength(str[i:]); n > 0 {
+			b.WriteString(str[i : i+n])
+			i += n
+
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(str[i:])
+
+		w := runeWidth(r)
+		if r == 0xfe0f && prev == 1 {
+			w = 1
+		}
+
+		if full || used+w > width {
+			full = true
+		} else {
+			b.WriteString(str[i : i+size])
+			used += w
+		}
+
+		prev = runeWidth(r)
+		i += size
+	}
+
+	return b.String()
+}
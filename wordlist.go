@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// normalizeWordList reads a word list one word per line, tolerating the
+// things editors tend to leave behind: a byte order mark, CRLF line endings,
+// stray whitespace, blank lines, and lowercase. Words are kept in the order
+// they're listed. Anything that isn't a five letter word, and any word listed
+// twice, is left out and reported with its line number.
+func normalizeWordList(name string, raw string) (words []string, problems []string) {
+	raw = strings.TrimPrefix(raw, "\ufeff")
+	seen := map[string]int{}
+
+	for i, line := range strings.Split(raw, "\n") {
+		w := strings.ToUpper(strings.TrimSpace(line))
+		if w == "" {
+			continue
+		}
+
+		if !isASCII(w) {
+			problems = append(problems, fmt.Sprintf("%s:%d: %q has letters outside A to Z", name, i+1, w))
+			continue
+		}
+
+		if !isFiveLetters(w) {
+			problems = append(problems, fmt.Sprintf("%s:%d: %q isn't a %d letter word", name, i+1, w, WordLength))
+			continue
+		}
+
+		if first, ok := seen[w]; ok {
+			problems = append(problems, fmt.Sprintf("%s:%d: %s is already on line %d", name, i+1, w, first))
+			continue
+		}
+
+		seen[w] = i + 1
+		words = append(words, w)
+	}
+
+	return words, problems
+}
+
+func isFiveLetters(w string) bool {
+	if len(w) != WordLength {
+		return false
+	}
+
+	for i := range w {
+		if w[i] < 'A' || w[i] > 'Z' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isASCII(w string) bool {
+	for i := range w {
+		if w[i] >= 0x80 {
+			return false
+		}
+	}
+
+	return true
+}
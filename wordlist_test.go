@@ -0,0 +1,61 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeWordList(t *testing.T) {
+	tests := []struct {
+		name     string
+		raw      string
+		words    []string
+		problems []string
+	}{
+		{
+			name:  "clean",
+			raw:   "CRANE\nSLATE\n",
+			words: []string{"CRANE", "SLATE"},
+		},
+		{
+			name:  "left behind by an editor",
+			raw:   "\ufeffcrane\r\n\r\n  Slate \r\nSHAKE",
+			words: []string{"CRANE", "SLATE", "SHAKE"},
+		},
+		{
+			name:  "not words",
+			raw:   "CRANE\nCRANES\nSLAT\nCR4NE\nCAFÉS\nSLATE\n",
+			words: []string{"CRANE", "SLATE"},
+			problems: []string{
+				`good_words.txt:2: "CRANES" isn't a 5 letter word`,
+				`good_words.txt:3: "SLAT" isn't a 5 letter word`,
+				`good_words.txt:4: "CR4NE" isn't a 5 letter word`,
+				`good_words.txt:5: "CAFÉS" has letters outside A to Z`,
+			},
+		},
+		{
+			name:     "listed twice",
+			raw:      "CRANE\nSLATE\n\ncrane\n",
+			words:    []string{"CRANE", "SLATE"},
+			problems: []string{"good_words.txt:4: CRANE is already on line 1"},
+		},
+		{
+			name: "empty",
+			raw:  "\ufeff\n\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			words, problems := normalizeWordList("good_words.txt", tt.raw)
+
+			if !reflect.DeepEqual(words, tt.words) {
+				t.Errorf("words = %q, want %q", words, tt.words)
+			}
+
+			if !reflect.DeepEqual(problems, tt.problems) {
+				t.Errorf("problems = %q, want %q", problems, tt.problems)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+type YesterdayCommand struct{}
+
+func (cmd *YesterdayCommand) Execute(_ []string) error {
+	gamestats := loadGameStats()
+
+	parseWordLists()
+	printYesterday(gamestats)
+
+	return nil
+}
+
+// printYesterday shows yesterday's daily answer and how it went, the way the
+// website does the day after. It's only shown when asked for with the
+// yesterday command or --yesterday, so it doesn't spoil a daily for anyone
+// still meaning to solve it some other way.
+func printYesterday(gs *GameStats) {
+	day := int(time.Since(dailyEpoch).Hours()/24) - 1
+	if day < 0 {
+		fmt.Println("There was no daily yesterday")
+		return
+	}
+
+	if args.Group != "" {
+		fmt.Printf("Yesterday's %s daily was %s\n", args.Group, wordList[groupWordIndex(args.Group, day)])
+		return
+	}
+
+	yesterday := &localProfile{stats: gs}
+	yesterday.findToday(day)
+
+	result := "you missed it"
+
+	switch {
+	case yesterday.today == "playing":
+		result = "you didn't finish it"
+	case yesterday.today != "":
+		result = "you got " + yesterday.today
+	}
+
+	fmt.Printf("Yesterday's daily (puzzle %d) was %s, %s\n", day+gs.PuzzleNumberOffset, wordList[gs.dailyIndex(day)], result)
+}